@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	samsung_tv_api "github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/apps"
+)
+
+const _appsUsage = `apps sub commands
+list                    Lists the installed and well-known applications
+search query            Lists the applications matching the query, best first
+launch name|id          Launches the application, eg samsungtv-cli apps launch netflix
+close name|id           Closes the application
+status name|id          Prints whether the application is running and visible
+foreground              Prints the application shown on the screen
+refresh                 Lists the installed applications again
+`
+
+// runApps runs the apps sub commands, the catalogue fetched from the TV being
+// cached in ~/.samsung.json.
+func runApps(tv *samsung_tv_api.SamsungTvClient, args []string) error {
+	if len(args) < 1 {
+		fmt.Print(_appsUsage)
+		return nil
+	}
+
+	ctx := context.Background()
+	defer saveConfig()
+
+	switch args[0] {
+	case "list", "search":
+		catalogue, err := tv.Apps(ctx)
+		if err != nil {
+			return err
+		}
+		printApps(catalogue.Search(strings.Join(args[1:], " ")))
+	case "launch":
+		if len(args) < 2 {
+			return errors.New("usage: samsungtv-cli apps launch name|id")
+		}
+		result, err := tv.LaunchApp(ctx, strings.Join(args[1:], " "))
+		fmt.Printf("%s: %s\n", result.ID, result.Outcome)
+		return err
+	case "close":
+		if len(args) < 2 {
+			return errors.New("usage: samsungtv-cli apps close name|id")
+		}
+		result, err := tv.CloseApp(ctx, strings.Join(args[1:], " "))
+		fmt.Printf("%s: %s\n", result.ID, result.Outcome)
+		return err
+	case "status":
+		if len(args) < 2 {
+			return errors.New("usage: samsungtv-cli apps status name|id")
+		}
+		status, err := tv.AppStatus(ctx, strings.Join(args[1:], " "))
+		if err != nil {
+			return err
+		}
+		printStatus(status)
+	case "foreground":
+		status, err := tv.AppManager.ForegroundApp(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(status)
+	case "refresh":
+		catalogue, err := tv.RefreshApps(ctx)
+		if err != nil {
+			return err
+		}
+		printApps(catalogue.Apps)
+	default:
+		fmt.Print(_appsUsage)
+	}
+
+	return nil
+}
+
+func printApps(list []apps.App) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	for _, app := range list {
+		installed := ""
+		if app.Installed {
+			installed = "installed"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", app.ID, app.Name, installed)
+	}
+}
+
+func printStatus(status apps.Status) {
+	fmt.Printf("%s\t%s\trunning: %v\tvisible: %v\n", status.ID, status.Name, status.Running, status.Visible)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	samsung_tv_api "github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/art"
+)
+
+const _artUsage = `art sub commands
+status                  Shows whether art mode is on
+on | off                Turns art mode on or off
+list [category]         Lists uploaded artwork, all artwork with "all"
+select id               Selects and shows the artwork
+upload file [matte]     Uploads a JPEG or PNG image
+delete id [id...]       Deletes the artwork
+matte                   Lists the matte types and colours
+matte id matte          Changes the matte of the artwork
+filter                  Lists the photo filters
+filter id filter        Applies the photo filter to the artwork
+brightness              Shows the art mode brightness
+colortemp               Shows the art mode colour temperature
+`
+
+// runArt runs the art sub command provided in args against the Frame TV.
+func runArt(tv *samsung_tv_api.SamsungTvClient, args []string) error {
+	if len(args) < 1 {
+		fmt.Print(_artUsage)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	if supported, err := tv.IsFrameTV(ctx); err == nil && !supported {
+		return errors.New("the TV does not support art mode")
+	}
+
+	defer tv.Art.Disconnect()
+
+	switch args[0] {
+	case "status":
+		on, err := tv.Art.GetArtMode(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("art mode on: %t\n", on)
+	case "on", "off":
+		return tv.Art.SetArtMode(ctx, args[0] == "on")
+	case "list":
+		category := art.MyPhotosCategory
+		if len(args) > 1 {
+			category = args[1]
+		}
+		if category == "all" {
+			category = ""
+		}
+		artwork, err := tv.Art.ListArtwork(ctx, category)
+		if err != nil {
+			return err
+		}
+		for _, item := range artwork {
+			fmt.Printf("%s - %s %dx%d matte: %s\n", item.ContentID, item.CategoryID, item.Width, item.Height, item.MatteID)
+		}
+	case "select":
+		if len(args) != 2 {
+			return errors.New("no artwork specified")
+		}
+		return tv.Art.SelectImage(ctx, args[1], true)
+	case "upload":
+		if len(args) < 2 {
+			return errors.New("no image file specified")
+		}
+		matte := ""
+		if len(args) > 2 {
+			matte = args[2]
+		}
+		id, err := tv.Art.Upload(ctx, args[1], matte)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("uploaded %s as %s\n", args[1], id)
+	case "delete":
+		if len(args) < 2 {
+			return errors.New("no artwork specified")
+		}
+		return tv.Art.Delete(ctx, args[1:]...)
+	case "matte":
+		if len(args) == 3 {
+			return tv.Art.ChangeMatte(ctx, args[1], args[2])
+		}
+		mattes, err := tv.Art.GetMatteList(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("types: %v\ncolors: %v\n", mattes.Types, mattes.Colors)
+	case "filter":
+		if len(args) == 3 {
+			return tv.Art.SetPhotoFilter(ctx, args[1], args[2])
+		}
+		filters, err := tv.Art.GetPhotoFilterList(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("filters: %v\n", filters)
+	case "brightness":
+		brightness, err := tv.Art.GetBrightness(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Println("brightness: " + strconv.Itoa(brightness))
+	case "colortemp":
+		temperature, err := tv.Art.GetColorTemperature(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Println("colour temperature: " + strconv.Itoa(temperature))
+	default:
+		fmt.Print(_artUsage)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/keys"
+)
+
+// listKeys prints the keys of the registry, only those whose code, name,
+// category or description contain every search term when provided.
+func listKeys(terms []string) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	for _, k := range keys.All() {
+		text := strings.ToLower(strings.Join([]string{k.Code, k.Name, k.Category, k.Description}, " "))
+		matches := true
+
+		for _, term := range terms {
+			if !strings.Contains(text, strings.ToLower(term)) {
+				matches = false
+			}
+		}
+
+		if matches {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", k.Code, k.Category, k.Description, strings.Join(k.Models, ", "))
+		}
+	}
+}
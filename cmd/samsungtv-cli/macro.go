@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/device"
+	samsung_tv_api "github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/macro"
+	"golang.org/x/term"
+)
+
+const _macroUsage = `macro sub commands
+list                    Lists the macros saved for the TV
+show name               Shows the steps of the macro
+run name|file           Runs the saved macro, or the macro in the file
+record name             Records the keys pressed into a new macro
+delete name             Deletes the macro
+file                    Runs the macro in the file
+`
+
+// runMacroConfig runs the macro sub commands which only read or change the
+// saved macros, returning false if args is any other sub command.
+func runMacroConfig(tv *device.DeviceInfo, args []string) (bool, error) {
+	if len(args) < 1 {
+		fmt.Print(_macroUsage)
+		return true, nil
+	}
+
+	client := samsung_tv_api.New(tv)
+
+	switch args[0] {
+	case "list":
+		for _, name := range client.Macros() {
+			fmt.Println(name)
+		}
+	case "show":
+		if len(args) != 2 {
+			return true, errors.New("usage: samsungtv-cli macro show name")
+		}
+		m, err := client.Macro(args[1])
+		if err != nil {
+			return true, err
+		}
+		fmt.Println(m)
+	case "delete":
+		if len(args) != 2 {
+			return true, errors.New("usage: samsungtv-cli macro delete name")
+		}
+		if err := client.DeleteMacro(args[1]); err != nil {
+			return true, err
+		}
+		saveConfig()
+	default:
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// runMacro runs the macro sub commands which need a connection to the TV.
+func runMacro(tv *samsung_tv_api.SamsungTvClient, args []string) error {
+	ctx := context.Background()
+
+	switch args[0] {
+	case "run":
+		if len(args) != 2 {
+			return errors.New("usage: samsungtv-cli macro run name|file")
+		}
+		err := tv.RunNamedMacro(ctx, args[1])
+		if !errors.Is(err, samsung_tv_api.ErrMacroNotFound) {
+			return err
+		}
+		return runMacroFile(tv, args[1])
+	case "record":
+		if len(args) != 2 {
+			return errors.New("usage: samsungtv-cli macro record name")
+		}
+		return recordMacro(tv, args[1])
+	}
+
+	if len(args) != 1 {
+		fmt.Print(_macroUsage)
+		return errors.New("unknown macro sub command")
+	}
+
+	return runMacroFile(tv, args[0])
+}
+
+// runMacroFile runs the macro in the file against the TV.
+func runMacroFile(tv *samsung_tv_api.SamsungTvClient, path string) error {
+	m, err := macro.Load(path)
+
+	if err != nil {
+		return err
+	}
+
+	return tv.RunMacro(context.Background(), m)
+}
+
+// _recordHelp lists the keys of the macro recorder besides those of the remote.
+const _recordHelp = "u undo, q save, ctrl-c cancel, ? keys"
+
+// recordMacro sends the keys typed in the terminal to the TV as the remote
+// does, recording each as a step with the time between presses as waits, and
+// saves them as the named macro once q is typed.
+func recordMacro(tv *samsung_tv_api.SamsungTvClient, name string) error {
+	fd := int(os.Stdin.Fd())
+
+	if !term.IsTerminal(fd) {
+		return errors.New("recording a macro requires an interactive terminal")
+	}
+
+	recorded, err := recordKeys(tv, name, fd)
+
+	if err != nil {
+		return err
+	}
+
+	return saveMacro(tv, name, recorded)
+}
+
+// recordKeys records the keys typed until q is typed, with the terminal in
+// raw mode.
+func recordKeys(tv *samsung_tv_api.SamsungTvClient, name string, fd int) (macro.Macro, error) {
+	defer silenceLog()()
+
+	oldState, err := term.MakeRaw(fd)
+
+	if err != nil {
+		return macro.Macro{}, err
+	}
+
+	defer func() {
+		_ = term.Restore(fd, oldState)
+		fmt.Println()
+	}()
+
+	ctx := context.Background()
+	r := &recording{name: name}
+	buf := make([]byte, 16)
+
+	for {
+		r.render()
+
+		n, err := os.Stdin.Read(buf)
+
+		if err != nil {
+			return r.macro, err
+		}
+
+		input := string(buf[:n])
+
+		switch input {
+		case "q":
+			return r.macro, nil
+		case "\x03", "\x04":
+			return r.macro, errors.New("recording cancelled")
+		case "u":
+			r.undo()
+			continue
+		case "?":
+			r.showHelp = !r.showHelp
+			continue
+		}
+
+		key, ok := _remoteKeys[input]
+
+		if !ok {
+			continue
+		}
+
+		r.lastErr = tv.Websocket.SendClickContext(ctx, key)
+
+		if r.lastErr == nil {
+			r.press(key, time.Now())
+		}
+	}
+}
+
+// recording is the macro recorded so far.
+type recording struct {
+	name  string
+	macro macro.Macro
+	// presses holds the index of the first step recorded for every press, its
+	// wait included, so a press can be undone.
+	presses  []int
+	last     time.Time
+	lastErr  error
+	showHelp bool
+}
+
+// press records the click of the key, preceded by a wait for the time since
+// the last press rounded to 100ms. Clicks of the same key without a wait in
+// between are recorded as a single step clicking the key several times.
+func (r *recording) press(key string, at time.Time) {
+	var wait time.Duration
+
+	if !r.last.IsZero() {
+		wait = at.Sub(r.last).Round(100 * time.Millisecond)
+	}
+
+	r.last = at
+	steps := r.macro.Steps
+
+	if wait == 0 && len(steps) > 0 && steps[len(steps)-1].Action == macro.Click && steps[len(steps)-1].Key == key {
+		steps[len(steps)-1].Times++
+		r.presses = append(r.presses, -1)
+		return
+	}
+
+	r.presses = append(r.presses, len(steps))
+
+	if wait > 0 {
+		steps = append(steps, macro.Step{Action: macro.Wait, Duration: macro.Duration(wait)})
+	}
+
+	r.macro.Steps = append(steps, macro.Step{Action: macro.Click, Key: key, Times: 1})
+}
+
+// undo drops the last press. The next press is recorded without a wait, as
+// the time since the undone press is not meaningful.
+func (r *recording) undo() {
+	if len(r.presses) == 0 {
+		return
+	}
+
+	first := r.presses[len(r.presses)-1]
+	r.presses = r.presses[:len(r.presses)-1]
+	r.last = time.Time{}
+
+	if first == -1 {
+		r.macro.Steps[len(r.macro.Steps)-1].Times--
+		return
+	}
+
+	r.macro.Steps = r.macro.Steps[:first]
+}
+
+// render redraws the recorded steps and the status line. The terminal is in
+// raw mode so every line must end with \r\n.
+func (r *recording) render() {
+	var out strings.Builder
+
+	out.WriteString("\x1b[2J\x1b[H")
+
+	if r.showHelp {
+		out.WriteString(strings.ReplaceAll(_remoteHelp, "\n", "\r\n"))
+		out.WriteString("\r\n")
+	}
+
+	for _, step := range r.macro.Steps {
+		fmt.Fprintf(&out, "  %s\r\n", step)
+	}
+
+	fmt.Fprintf(&out, "recording %q, %d steps", r.name, len(r.macro.Steps))
+
+	if r.lastErr != nil {
+		fmt.Fprintf(&out, " | error: %v", r.lastErr)
+	}
+
+	fmt.Fprintf(&out, " | %s", _recordHelp)
+
+	_, _ = os.Stdout.WriteString(out.String())
+}
+
+func saveMacro(tv *samsung_tv_api.SamsungTvClient, name string, m macro.Macro) error {
+	if len(m.Steps) == 0 {
+		return errors.New("no steps recorded")
+	}
+
+	if err := tv.SaveMacro(name, m); err != nil {
+		return err
+	}
+
+	saveConfig()
+	fmt.Printf("Saved macro %q with %d steps.\n", name, len(m.Steps))
+	return nil
+}
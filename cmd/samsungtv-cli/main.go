@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"errors"
 
 	"github.com/stephensli/samsung-tv-api/pkg/device"
 	samsung_tv_api "github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/store"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
 	sonos_api "github.com/stephensli/samsung-tv-api/pkg/sonos-api"
 
 	//"github.com/davecgh/go-spew/spew"
@@ -18,6 +21,10 @@ import (
 
 var devices_ []device.DeviceInfo
 
+// tokens_ holds the tokens issued by the TVs, which are kept out of
+// ~/.samsung.json.
+var tokens_ store.TokenStore
+
 func zeroconfDisco() {
 	samsungs := samsung_tv_api.Discover()
 	for _, dev := range samsungs {
@@ -40,12 +47,18 @@ func saveConfig() {
 	if devices_ == nil {
 		return
 	}
-	configBytes, err := json.MarshalIndent(devices_, "", "  ")
+	// tokens are kept in the token store only.
+	devices := make([]device.DeviceInfo, len(devices_))
+	copy(devices, devices_)
+	for i := range devices {
+		devices[i].Token = ""
+	}
+	configBytes, err := json.MarshalIndent(devices, "", "  ")
 	if err != nil {
 		fmt.Printf("1 %v", err)
 	}
 	homeDir, _ := os.UserHomeDir()
-	err = os.WriteFile(homeDir+"/.samsung.json", configBytes, 0644)
+	err = os.WriteFile(homeDir+"/.samsung.json", configBytes, 0600)
 	if err != nil {
 		fmt.Printf("2 %v", err)
 	}
@@ -74,6 +87,36 @@ func loadConfig() {
 	if err != nil {
 		panic(fmt.Errorf("\n~/.samsung.json is corrupt\n %v", err))
 	}
+	migrateTokens()
+}
+
+// openTokenStore returns the token store selected by the -store flag.
+func openTokenStore(kind string) (store.TokenStore, error) {
+	switch kind {
+	case "file":
+		return store.NewFileStore()
+	case "env":
+		return store.EnvStore{}, nil
+	case "memory":
+		return store.NewMemoryStore(), nil
+	}
+	return nil, fmt.Errorf("unknown token store %s, expected file, env or memory", kind)
+}
+
+// migrateTokens moves tokens written to ~/.samsung.json by earlier versions
+// into the token store.
+func migrateTokens() {
+	for _, d := range devices_ {
+		if d.Token == "" {
+			continue
+		}
+		if _, err := tokens_.Token(d.Key()); !errors.Is(err, store.ErrNotFound) {
+			continue
+		}
+		if err := tokens_.SaveToken(d.Key(), d.Token); err != nil {
+			log.Printf("unable to migrate token of %s: %v", d.Name, err)
+		}
+	}
 }
 
 const _usage = `Sub commands
@@ -81,11 +124,17 @@ DEVICE CONTROL
 devices  Does a scan on the local network to find devices
 ip  eg samsung-tv-api ip 192.168.1.2   Creates a device record for IP address
 discover
+pair     Pairs with the TV, asking to allow the connection on the TV
+trust    Trusts the certificate the TV presents, needed after resetting the TV
+wake     Sends wake on lan to the TV, see samsungtv-cli wake -h
 COMMANDS
 poweroff
 list
-open
-key keynumber
+open     url, app name or id, eg samsungtv-cli open netflix
+apps     Lists, searches and launches applications, see samsungtv-cli apps
+watch    Prints changes of the shown application, eg samsungtv-cli watch apps
+key name  eg samsungtv-cli key "vol up", see samsungtv-cli keys
+keys     Lists the known keys, eg samsungtv-cli keys volume
 volup
 voldown
 vol value
@@ -98,6 +147,10 @@ prev
 pause
 play
 status
+art      Frame TV art mode, see samsungtv-cli art
+macro    Runs, records and lists macros, see samsungtv-cli macro
+remote   Interactive remote keeping one connection open, ? shows the keys
+pointer  Drives the pointer from the terminal, eg samsungtv-cli pointer https://example.com
 `
 
 func setUpFlag() {
@@ -114,7 +167,11 @@ func setUpFlag() {
 
 func main() {
 	deviceId := 0
+	tokenStore := "file"
+	forceKeys := false
 	flag.IntVar(&deviceId, "d", 0, "Device or speaker id is not defined, 0 default")
+	flag.StringVar(&tokenStore, "store", "file", "Where tokens are kept: file, env or memory")
+	flag.BoolVar(&forceKeys, "force", false, "Sends keys missing from the key registry")
 	setUpFlag()
 
 	Args := flag.Args()
@@ -125,6 +182,16 @@ func main() {
 		return
 	}
 
+	var err error
+	if tokens_, err = openTokenStore(tokenStore); err != nil {
+		log.Fatal(err)
+	}
+
+	if Args[0] == "keys" {
+		listKeys(Args[1:])
+		return
+	}
+
 	loadConfig()
 	if Args[0] == "devices" {
 		for id, d := range devices_ {
@@ -155,10 +222,51 @@ func main() {
 	var tv device.DeviceInfo
 	tv = devices_[deviceId]
 	log.Printf("Device: %#v", tv)
+	if Args[0] == "pair" {
+		if tv.Type != "samsungtv" {
+			log.Fatal("pairing is only supported by Samsung TVs")
+		}
+		if err := runPair(&devices_[deviceId]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if Args[0] == "macro" && tv.Type == "samsungtv" {
+		done, err := runMacroConfig(&devices_[deviceId], Args[1:])
+		if err != nil {
+			log.Fatal(err)
+		}
+		if done {
+			return
+		}
+	}
+	if Args[0] == "wake" {
+		if err := runWake(&devices_[deviceId], Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if Args[0] == "trust" {
+		if tv.Type != "samsungtv" {
+			log.Fatal("trusting certificates is only supported by Samsung TVs")
+		}
+		if err := runTrust(&devices_[deviceId]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	var devApi device.Device
 	if tv.Type == "samsungtv" {
-		devApi = samsung_tv_api.NewSamsungTvWebSocket(&devices_[deviceId], 0, false)
-		devApi.Init()
+		tvApi := samsung_tv_api.NewSamsungTvWebSocket(&devices_[deviceId], 0, false)
+		tvApi.TokenStore = tokens_
+		tvApi.Websocket.AllowUnknownKeys = forceKeys
+		if err := tvApi.InitContext(context.Background()); errors.Is(err, tverrors.ErrCertificateMismatch) {
+			log.Fatalf("%v\nif the TV was reset run samsungtv-cli trust", err)
+		} else if err != nil {
+			log.Fatal(err)
+		}
+		devApi = tvApi
 		saveConfig()
 	} else if tv.Type == "sonos" {
 		devApi = sonos_api.NewSonosDevice(tv.Ip)
@@ -167,13 +275,83 @@ func main() {
 		return
 	}
 
+	if Args[0] == "art" {
+		tvApi, ok := devApi.(*samsung_tv_api.SamsungTvClient)
+		if !ok {
+			log.Fatal("art mode is only supported by Samsung TVs")
+		}
+		if err := runArt(tvApi, Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if Args[0] == "macro" {
+		tvApi, ok := devApi.(*samsung_tv_api.SamsungTvClient)
+		if !ok {
+			log.Fatal("macros are only supported by Samsung TVs")
+		}
+		if err := runMacro(tvApi, Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if Args[0] == "remote" {
+		tvApi, ok := devApi.(*samsung_tv_api.SamsungTvClient)
+		if !ok {
+			log.Fatal("the interactive remote is only supported by Samsung TVs")
+		}
+		if err := runRemote(tvApi); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if Args[0] == "apps" {
+		tvApi, ok := devApi.(*samsung_tv_api.SamsungTvClient)
+		if !ok {
+			log.Fatal("applications are only supported by Samsung TVs")
+		}
+		if err := runApps(tvApi, Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if Args[0] == "watch" {
+		tvApi, ok := devApi.(*samsung_tv_api.SamsungTvClient)
+		if !ok {
+			log.Fatal("watching is only supported by Samsung TVs")
+		}
+		if err := runWatch(tvApi, Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if Args[0] == "pointer" {
+		tvApi, ok := devApi.(*samsung_tv_api.SamsungTvClient)
+		if !ok {
+			log.Fatal("the pointer is only supported by Samsung TVs")
+		}
+		if err := runPointer(tvApi, Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	if Args[0] == "poweroff" {
-		devApi.PowerOff()
+		if err := devApi.PowerOff(); err != nil {
+			log.Fatal(err)
+		}
 		return
 	}
 
 	if Args[0] == "list" {
-		devApi.List()
+		if err := devApi.List(); err != nil {
+			log.Fatal(err)
+		}
 		return
 	}
 
@@ -181,35 +359,48 @@ func main() {
 		if flag.NArg() != 2 {
 			log.Fatal("no url or app specified")
 		}
-		devApi.Open(Args[1])
+		if err := devApi.Open(Args[1]); err != nil {
+			log.Fatal(err)
+		}
 		return
 	}
 	if Args[0] == "key" {
 		if flag.NArg() != 2 {
 			log.Fatal("no key specified")
 		}
-		devApi.Key(Args[1])
+		if err := devApi.Key(Args[1]); err != nil {
+			log.Fatal(err)
+		}
 		return
 	}
 	if Args[0] == "volup" {
-		devApi.VolUp()
+		if err := devApi.VolUp(); err != nil {
+			log.Fatal(err)
+		}
 		return
 	}
 
 	if Args[0] == "voldown" {
-		devApi.VolDown()
+		if err := devApi.VolDown(); err != nil {
+			log.Fatal(err)
+		}
 		return
 	}
 
 	if Args[0] == "vol" {
 		log.Printf("%d", flag.NArg())
 		if flag.NArg() != 2 {
-			vol, _ := devApi.Vol(-1)
+			vol, err := devApi.Vol(-1)
+			if err != nil {
+				log.Fatal(err)
+			}
 			log.Printf("volume is %d", vol)
 			return
 		}
 		intValue, _ := strconv.Atoi(Args[1])
-		devApi.Vol(intValue)
+		if _, err := devApi.Vol(intValue); err != nil {
+			log.Fatal(err)
+		}
 		return
 	}
 
@@ -221,7 +412,9 @@ func main() {
 		if flag.NArg() != 2 {
 			log.Fatal("no key specified")
 		}
-		devApi.Text(Args[1])
+		if err := devApi.Text(Args[1]); err != nil {
+			log.Fatal(err)
+		}
 		return
 	}
 
@@ -230,29 +423,38 @@ func main() {
 			log.Fatal("no key specified")
 		}
 		log.Printf("Streaming %s", os.Args[2])
-		devApi.Stream(os.Args[2])
+		if err := devApi.Stream(os.Args[2]); err != nil {
+			log.Fatal(err)
+		}
 		return
 	}
 
 	if Args[0] == "status" {
-		devApi.Status()
+		if _, err := devApi.Status(); err != nil {
+			log.Fatal(err)
+		}
 		return
 	}
 
 	if Args[0] == "next" {
-		devApi.Next()
+		if err := devApi.Next(); err != nil {
+			log.Fatal(err)
+		}
 	}
 	if Args[0] == "prev" {
-		devApi.Prev()
+		if err := devApi.Prev(); err != nil {
+			log.Fatal(err)
+		}
 	}
 	if Args[0] == "pause" {
-		devApi.Pause()
+		if err := devApi.Pause(); err != nil {
+			log.Fatal(err)
+		}
 	}
 	if Args[0] == "play" {
-		devApi.Play()
-	}
-	if Args[0] == "status" {
-		devApi.Status()
+		if err := devApi.Play(); err != nil {
+			log.Fatal(err)
+		}
 	}
 }
 
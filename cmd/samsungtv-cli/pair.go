@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/device"
+	samsung_tv_api "github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+)
+
+// runPair walks the user through allowing the CLI on the TV, storing the
+// issued token for later commands.
+func runPair(tv *device.DeviceInfo) error {
+	client := samsung_tv_api.NewSamsungTvWebSocket(tv, 0, false)
+	client.TokenStore = tokens_
+	client.OnPairingState = func(state samsung_tv_api.PairingState) {
+		switch state {
+		case samsung_tv_api.PairingAwaitingApproval:
+			fmt.Println("Please select \"Allow\" on the prompt shown on your TV...")
+		case samsung_tv_api.PairingApproved:
+			fmt.Println("Connection allowed.")
+		case samsung_tv_api.PairingDenied:
+			fmt.Println("Connection denied on the TV.")
+		case samsung_tv_api.PairingTimedOut:
+			fmt.Println("No response was given on the TV in time.")
+		}
+	}
+
+	fmt.Printf("Pairing with %s (%s)\n", tv.Name, tv.Ip)
+
+	if !client.IsAlive() {
+		return errors.New("the TV is not responding, make sure it is turned on")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	// pairing should always show the prompt, so ignore any existing token.
+	tv.Token = ""
+
+	_, err := client.Pair(ctx)
+	defer client.Disconnect()
+
+	if errors.Is(err, tverrors.ErrUnauthorized) {
+		return errors.New("to pair again, remove the device from the TV's device connection manager and retry")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// keep the device id the token was stored against.
+	saveConfig()
+	fmt.Println("Paired, token saved.")
+	return nil
+}
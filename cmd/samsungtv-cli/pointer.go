@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	samsung_tv_api "github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/keys"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/websocket"
+	"golang.org/x/term"
+)
+
+const _pointerHelp = `
+  arrows      move 20px          shift+arrows  move 100px
+  h j k l     move 5px           enter, space  click
+  page up/dn  scroll             u / d         scroll
+  0           pointer home       b             back
+  ? toggle help                  q quit
+`
+
+// _pointerMoves maps the input read from the terminal to a pointer offset.
+var _pointerMoves = map[string]websocket.Point{
+	"\x1b[A":    {Y: -20},
+	"\x1b[B":    {Y: 20},
+	"\x1b[C":    {X: 20},
+	"\x1b[D":    {X: -20},
+	"\x1b[1;2A": {Y: -100},
+	"\x1b[1;2B": {Y: 100},
+	"\x1b[1;2C": {X: 100},
+	"\x1b[1;2D": {X: -100},
+	"k":         {Y: -5},
+	"j":         {Y: 5},
+	"l":         {X: 5},
+	"h":         {X: -5},
+}
+
+// _pointerScrolls maps the input read from the terminal to a scroll offset.
+var _pointerScrolls = map[string]int{
+	"\x1b[5~": -120,
+	"\x1b[6~": 120,
+	"u":       -120,
+	"d":       120,
+}
+
+// runPointer drives the pointer of the TV from the terminal, opening the
+// browser at the url first when provided.
+func runPointer(tv *samsung_tv_api.SamsungTvClient, args []string) error {
+	fd := int(os.Stdin.Fd())
+
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("the pointer requires an interactive terminal")
+	}
+
+	// registered first so the log is restored once everything is stopped.
+	defer silenceLog()()
+
+	ctx := context.Background()
+	ws := &tv.Websocket
+	defer tv.Disconnect()
+
+	if len(args) > 0 {
+		if err := ws.OpenBrowserContext(ctx, args[0]); err != nil {
+			return err
+		}
+	}
+
+	oldState, err := term.MakeRaw(fd)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = term.Restore(fd, oldState)
+		fmt.Println()
+	}()
+
+	showHelp := false
+	status := ""
+	buf := make([]byte, 16)
+
+	for {
+		renderPointer(ws, showHelp, status)
+
+		n, err := os.Stdin.Read(buf)
+
+		if err != nil {
+			return err
+		}
+
+		input := string(buf[:n])
+
+		if move, ok := _pointerMoves[input]; ok {
+			err = ws.MovePointer(ctx, move.X, move.Y)
+		} else if scroll, ok := _pointerScrolls[input]; ok {
+			err = ws.Scroll(ctx, 0, scroll)
+		} else {
+			switch input {
+			case "q", "\x03", "\x04":
+				return nil
+			case "?":
+				showHelp = !showHelp
+			case "\r", "\n", " ":
+				err = ws.LeftClick(ctx)
+			case "0":
+				err = ws.HomePointer(ctx)
+			case "b":
+				err = ws.SendClickContext(ctx, keys.NavigationReturn)
+			}
+		}
+
+		status = ""
+
+		if err != nil {
+			status = fmt.Sprintf(" | error: %v", err)
+		}
+	}
+}
+
+// renderPointer redraws the help, when shown, and the status line. The
+// terminal is in raw mode so every line must end with \r\n.
+func renderPointer(ws *websocket.SamsungWebsocket, showHelp bool, status string) {
+	var out strings.Builder
+
+	out.WriteString("\x1b[2J\x1b[H")
+
+	if showHelp {
+		out.WriteString(strings.ReplaceAll(_pointerHelp, "\n", "\r\n"))
+		out.WriteString("\r\n")
+	}
+
+	position := "unknown, 0 homes it"
+
+	if p, ok := ws.Pointer(); ok {
+		position = fmt.Sprintf("%d,%d", p.X, p.Y)
+	}
+
+	fmt.Fprintf(&out, "[%s] pointer %s%s | ? help, q quit", ws.State(), position, status)
+
+	_, _ = os.Stdout.WriteString(out.String())
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	samsung_tv_api "github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/keys"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/websocket"
+	"golang.org/x/term"
+)
+
+const _remoteHelp = `
+  arrows    navigate            enter      select
+  backspace back                esc        exit
+  0-9       digits              + / -      volume up / down
+  h home    m menu    s source  i info     g guide    t tools
+  x mute    p play    space pause           f forward  r rewind
+  [ / ]     channel down / up   l channel list
+  o power   ? toggle help       q quit
+`
+
+// _remoteKeys maps the input read from the terminal to the key sent to the TV.
+var _remoteKeys = map[string]string{
+	"\x1b[A": keys.NavigationUp,
+	"\x1b[B": keys.NavigationDown,
+	"\x1b[C": keys.NavigationRight,
+	"\x1b[D": keys.NavigationLeft,
+	"\r":     keys.NavigationEnter,
+	"\n":     keys.NavigationEnter,
+	"\x7f":   keys.NavigationReturn,
+	"\b":     keys.NavigationReturn,
+	"\x1b":   keys.NavigationExit,
+	"+":      keys.VolumeUp,
+	"=":      keys.VolumeUp,
+	"-":      keys.VolumeDown,
+	"h":      keys.Home,
+	"m":      keys.Menu,
+	"s":      keys.Source,
+	"i":      keys.Info,
+	"g":      keys.Guide,
+	"t":      keys.Tools,
+	"x":      keys.Mute,
+	"p":      keys.Play,
+	" ":      keys.Pause,
+	"f":      keys.FastForward,
+	"r":      keys.Rewind,
+	"[":      keys.ChannelDown,
+	"]":      keys.ChannelUp,
+	"l":      keys.ChannelList,
+	"o":      keys.Power,
+}
+
+func init() {
+	for digit := '0'; digit <= '9'; digit++ {
+		_remoteKeys[string(digit)] = "KEY_" + string(digit)
+	}
+}
+
+// remote is the state shown on the status line of the interactive remote.
+type remote struct {
+	tv       *samsung_tv_api.SamsungTvClient
+	mutex    sync.Mutex
+	state    websocket.ConnectionState
+	volume   int
+	lastKey  string
+	lastErr  error
+	showHelp bool
+}
+
+// runRemote keeps the connection with the TV open, sending the keys typed in
+// the terminal until q or ctrl-c is typed.
+func runRemote(tv *samsung_tv_api.SamsungTvClient) error {
+	fd := int(os.Stdin.Fd())
+
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("the remote requires an interactive terminal")
+	}
+
+	// registered first so the log is restored once everything is stopped.
+	defer silenceLog()()
+
+	tv.EnableReconnect(websocket.ReconnectPolicy{})
+	defer tv.Disconnect()
+
+	r := &remote{tv: tv, state: tv.Websocket.State(), volume: -1}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go r.watchState(ctx)
+	go r.pollVolume(ctx)
+
+	oldState, err := term.MakeRaw(fd)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = term.Restore(fd, oldState)
+		fmt.Println()
+	}()
+
+	r.render()
+
+	buf := make([]byte, 16)
+
+	for {
+		n, err := os.Stdin.Read(buf)
+
+		if err != nil {
+			return err
+		}
+
+		input := string(buf[:n])
+
+		switch input {
+		case "q", "\x03", "\x04":
+			return nil
+		case "?":
+			r.mutex.Lock()
+			r.showHelp = !r.showHelp
+			r.mutex.Unlock()
+			r.render()
+			continue
+		}
+
+		key, ok := _remoteKeys[input]
+
+		if !ok {
+			continue
+		}
+
+		err = tv.Websocket.SendClickContext(ctx, key)
+
+		r.mutex.Lock()
+		r.lastKey = key
+		r.lastErr = err
+		r.mutex.Unlock()
+
+		if key == keys.VolumeUp || key == keys.VolumeDown || key == keys.Mute {
+			go r.updateVolume(ctx)
+		}
+
+		r.render()
+	}
+}
+
+// silenceLog discards the output of the log package until the returned function
+// is called. The clients log every command sent, which would otherwise be
+// written over the screen drawn while the terminal is in raw mode.
+func silenceLog() func() {
+	out := log.Writer()
+	log.SetOutput(io.Discard)
+
+	return func() {
+		log.SetOutput(out)
+	}
+}
+
+// watchState updates the connection state shown as it changes.
+func (r *remote) watchState(ctx context.Context) {
+	events := r.tv.Websocket.Subscribe(websocket.ConnectionStateEvent)
+	defer r.tv.Websocket.Unsubscribe(events)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev := <-events:
+			var change websocket.StateChange
+
+			if err := json.Unmarshal(ev.Data, &change); err != nil {
+				continue
+			}
+
+			r.mutex.Lock()
+			r.state = change.State
+			r.mutex.Unlock()
+			r.render()
+		}
+	}
+}
+
+// pollVolume refreshes the volume shown, as it can also be changed with the
+// remote of the TV.
+func (r *remote) pollVolume(ctx context.Context) {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		r.updateVolume(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *remote) updateVolume(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	volume, err := r.tv.Upnp.GetCurrentVolumeContext(ctx)
+
+	if err != nil {
+		volume = -1
+	}
+
+	r.mutex.Lock()
+	r.volume = volume
+	r.mutex.Unlock()
+	r.render()
+}
+
+// render redraws the help, when shown, and the status line. The terminal is in
+// raw mode so every line must end with \r\n.
+func (r *remote) render() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var out strings.Builder
+
+	// clear the screen and move the cursor home
+	out.WriteString("\x1b[2J\x1b[H")
+
+	if r.showHelp {
+		out.WriteString(strings.ReplaceAll(_remoteHelp, "\n", "\r\n"))
+		out.WriteString("\r\n")
+	}
+
+	volume := "?"
+	if r.volume >= 0 {
+		volume = fmt.Sprint(r.volume)
+	}
+
+	fmt.Fprintf(&out, "[%s] volume %s", r.state, volume)
+
+	if r.lastKey != "" {
+		fmt.Fprintf(&out, " | sent %s", r.lastKey)
+	}
+
+	if r.lastErr != nil {
+		fmt.Fprintf(&out, " | error: %v", r.lastErr)
+	}
+
+	out.WriteString(" | ? help, q quit")
+
+	_, _ = os.Stdout.WriteString(out.String())
+}
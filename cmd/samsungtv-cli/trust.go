@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/device"
+	samsung_tv_api "github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api"
+)
+
+// runTrust replaces the trusted certificate of the TV with the certificate it
+// currently presents, after the user confirms the new fingerprint.
+func runTrust(tv *device.DeviceInfo) error {
+	client := samsung_tv_api.New(tv)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	fingerprint, err := client.FetchFingerprint(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if fingerprint == tv.CertFingerprint {
+		fmt.Printf("The certificate of %s is already trusted.\n", tv.Name)
+		return nil
+	}
+
+	if tv.CertFingerprint != "" {
+		fmt.Printf("Trusted certificate: %s\n", tv.CertFingerprint)
+	}
+
+	fmt.Printf("Current certificate: %s\n", fingerprint)
+	fmt.Print("Only trust the certificate if the TV was reset. Trust it? [y/N] ")
+
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+
+	if strings.ToLower(strings.TrimSpace(answer)) != "y" {
+		return fmt.Errorf("certificate not trusted")
+	}
+
+	tv.CertFingerprint = fingerprint
+	saveConfig()
+
+	fmt.Println("Certificate trusted.")
+	return nil
+}
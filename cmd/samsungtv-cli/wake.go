@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/device"
+	samsung_tv_api "github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api"
+)
+
+// runWake sends the magic packet turning on the TV, configured by the flags
+// provided in args.
+func runWake(tv *device.DeviceInfo, args []string) error {
+	var opts samsung_tv_api.WakeOptions
+
+	flags := flag.NewFlagSet("wake", flag.ContinueOnError)
+	flags.StringVar(&opts.Broadcast, "broadcast", "", "Broadcast address, the subnet of the TV by default")
+	flags.StringVar(&opts.Interface, "iface", "", "Network interface to send from")
+	flags.StringVar(&opts.Source, "source", "", "Local address to send from")
+	flags.IntVar(&opts.Port, "port", 9, "UDP port, 7 or 9")
+	flags.IntVar(&opts.Count, "count", 3, "Number of packets to send")
+	flags.DurationVar(&opts.Interval, "interval", 100*time.Millisecond, "Time between packets")
+	flags.StringVar(&opts.Password, "password", "", "SecureOn password, e.g. 01:02:03:04:05:06")
+	flags.BoolVar(&opts.Raw, "raw", false, "Send a raw Ethernet frame on -iface (linux, needs root)")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if tv.Mac == "" {
+		return errors.New("the mac address of the TV is unknown, connect once while it is on")
+	}
+
+	client := samsung_tv_api.New(tv)
+	client.WakeOptions = opts
+
+	if err := client.Wake(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Sent wake on lan to %s (%s)\n", tv.Name, tv.Mac)
+	return nil
+}
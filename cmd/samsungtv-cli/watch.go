@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	samsung_tv_api "github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/apps"
+)
+
+const _watchUsage = `watch sub commands
+apps [-interval 2s] [name|id...]   Prints every change of the application shown on the screen
+`
+
+// runWatch runs the watch sub commands until interrupted.
+func runWatch(tv *samsung_tv_api.SamsungTvClient, args []string) error {
+	if len(args) < 1 || args[0] != "apps" {
+		fmt.Print(_watchUsage)
+		return nil
+	}
+
+	var opts apps.WatchOptions
+
+	flags := flag.NewFlagSet("watch apps", flag.ContinueOnError)
+	flags.DurationVar(&opts.Interval, "interval", apps.DefaultWatchInterval, "Time between polls of the applications")
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	defer saveConfig()
+
+	for _, name := range flags.Args() {
+		app, err := tv.FindApp(ctx, name)
+		if err != nil {
+			return err
+		}
+		opts.Apps = append(opts.Apps, app.ID)
+	}
+
+	// events of the websocket notice changes sooner, polling works without.
+	if err := tv.ConnectionSetupContext(ctx); err != nil {
+		log.Printf("unable to connect to the websocket api, polling only: %v", err)
+	} else {
+		defer tv.Disconnect()
+	}
+
+	fmt.Println("Watching the shown application, ctrl-c to stop")
+
+	for change := range tv.WatchApps(ctx, opts) {
+		fmt.Printf("%s\t%s -> %s\n", change.At.Format(time.TimeOnly), appLabel(change.From), appLabel(change.To))
+	}
+
+	return nil
+}
+
+// appLabel names the application of the status, no application being shown
+// while watching live TV or browsing the home screen.
+func appLabel(status apps.Status) string {
+	switch {
+	case status.ID == "":
+		return "tv/home"
+	case status.Name == "":
+		return status.ID
+	}
+
+	return status.Name
+}
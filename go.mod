@@ -3,22 +3,16 @@ module github.com/stephensli/samsung-tv-api
 go 1.22
 
 require (
-	github.com/basgys/goxml2json v1.1.0
 	github.com/stretchr/testify v1.7.0
 	golang.org/x/net v0.0.0-20211123203042-d83791d6bcd9
+	golang.org/x/term v0.5.0
 )
 
 require (
-	github.com/bitly/go-simplejson v0.5.0 // indirect
-	github.com/cenkalti/backoff v2.2.1+incompatible // indirect
 	github.com/davecgh/go-spew v1.1.0 // indirect
-	github.com/miekg/dns v1.1.27 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/crypto v0.0.0-20191011191535-87dc89f01550 // indirect
-	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c // indirect
-	golang.org/x/sys v0.0.0-20210423082822-04245dca01da // indirect
-	golang.org/x/text v0.3.7 // indirect
+	golang.org/x/sys v0.5.0 // indirect
 	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
 )
 
-replace github.com/stephensli/samsung-tv-api => .
\ No newline at end of file
+replace github.com/stephensli/samsung-tv-api => .
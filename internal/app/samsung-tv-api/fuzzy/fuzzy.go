@@ -0,0 +1,49 @@
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+)
+
+// This package holds the loose matching shared by the key registry and the
+// application catalogue, so "Vol-Up" matches "volup" and "netflx" "netflix".
+
+// Normalize lower cases the text, removing anything other than letters and
+// digits.
+func Normalize(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+
+		return -1
+	}, text)
+}
+
+// Distance returns the number of single character edits between a and b.
+func Distance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
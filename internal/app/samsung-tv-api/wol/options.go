@@ -0,0 +1,249 @@
+package wol
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+// LimitedBroadcast is the address magic packets are sent to by default.
+const LimitedBroadcast = "255.255.255.255"
+
+// EtherType is the EtherType of magic packets sent as raw Ethernet frames.
+const EtherType = 0x0842
+
+// Options configures how a magic packet is sent by Wake, the zero value sends
+// a single packet to 255.255.255.255:9.
+type Options struct {
+	// Broadcast is the address the packet is sent to, LimitedBroadcast when
+	// empty. See DirectedBroadcast for reaching a TV through a router.
+	Broadcast string
+	// Interface is the name of the network interface the packet is sent
+	// from, using its first IPv4 address as the source address. Required
+	// when sending raw Ethernet frames.
+	Interface string
+	// Source is the local IPv4 address the packet is sent from, taking
+	// precedence over the address of the Interface.
+	Source string
+	// Port is the UDP port the packet is sent to, usually 7 or 9. Defaults
+	// to 9.
+	Port int
+	// Count is the number of packets sent in a burst. Defaults to 1.
+	Count int
+	// Interval is the time between packets of a burst. Defaults to 100ms.
+	Interval time.Duration
+	// Password is the optional SecureOn password appended to the packet,
+	// written as six hex bytes like a mac address.
+	Password string
+	// Raw sends the packet as an Ethernet frame with EtherType 0x0842 on the
+	// Interface rather than over UDP, which requires linux and the
+	// CAP_NET_RAW capability.
+	Raw bool
+}
+
+func (o Options) withDefaults() Options {
+	if o.Broadcast == "" {
+		o.Broadcast = LimitedBroadcast
+	}
+
+	if o.Port <= 0 {
+		o.Port = 9
+	}
+
+	if o.Count <= 0 {
+		o.Count = 1
+	}
+
+	if o.Interval <= 0 {
+		o.Interval = 100 * time.Millisecond
+	}
+
+	return o
+}
+
+// Wake sends the magic packet waking the device with the given mac address as
+// configured by the options.
+func Wake(macAddr string, opts Options) error {
+	opts = opts.withDefaults()
+
+	packet, err := NewMagicPacket(macAddr)
+
+	if err != nil {
+		return err
+	}
+
+	payload, err := packet.WithPassword(opts.Password)
+
+	if err != nil {
+		return err
+	}
+
+	send, err := newSender(opts)
+
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < opts.Count; i++ {
+		if i > 0 {
+			time.Sleep(opts.Interval)
+		}
+
+		if err := send(payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newSender returns the function sending a payload as configured.
+func newSender(opts Options) (func([]byte) error, error) {
+	if opts.Raw {
+		if opts.Interface == "" {
+			return nil, errors.New("an interface is required to send raw ethernet frames")
+		}
+
+		return rawSender(opts.Interface)
+	}
+
+	var local *net.UDPAddr
+
+	source := opts.Source
+
+	if source == "" && opts.Interface != "" {
+		addr, err := interfaceAddr(opts.Interface)
+
+		if err != nil {
+			return nil, err
+		}
+
+		source = addr.String()
+	}
+
+	if source != "" {
+		ip := net.ParseIP(source)
+
+		if ip == nil {
+			return nil, fmt.Errorf("invalid source address %s", source)
+		}
+
+		local = &net.UDPAddr{IP: ip}
+	}
+
+	remote, err := net.ResolveUDPAddr("udp4", net.JoinHostPort(opts.Broadcast, strconv.Itoa(opts.Port)))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return func(payload []byte) error {
+		conn, err := net.DialUDP("udp4", local, remote)
+
+		if err != nil {
+			return err
+		}
+
+		defer func(conn net.Conn) {
+			_ = conn.Close()
+		}(conn)
+
+		_, err = conn.Write(payload)
+		return err
+	}, nil
+}
+
+// WithPassword returns the packet with the SecureOn password appended, or the
+// packet alone when the password is empty.
+func (mp MagicPacket) WithPassword(password string) ([]byte, error) {
+	if password == "" {
+		return mp[:], nil
+	}
+
+	raw, err := net.ParseMAC(password)
+
+	if err != nil || len(raw) != 6 {
+		return nil, fmt.Errorf("invalid SecureOn password %q, expected six hex bytes", password)
+	}
+
+	return append(mp[:], raw...), nil
+}
+
+// DirectedBroadcast returns the broadcast address of the subnet of the given
+// IPv4 address. The mask of the local interface on that subnet is used when
+// there is one, otherwise a /24 network is assumed, as routed TVs usually sit
+// on one.
+func DirectedBroadcast(ipAddr string) (string, error) {
+	ip := net.ParseIP(ipAddr).To4()
+
+	if ip == nil {
+		return "", fmt.Errorf("invalid IPv4 address %s", ipAddr)
+	}
+
+	mask := net.CIDRMask(24, 32)
+
+	if addrs, err := net.InterfaceAddrs(); err == nil {
+		for _, addr := range addrs {
+			if network, ok := addr.(*net.IPNet); ok && network.IP.To4() != nil && network.Contains(ip) {
+				mask = network.Mask
+				break
+			}
+		}
+	}
+
+	return broadcastAddr(ip, mask).String(), nil
+}
+
+// broadcastAddr returns the broadcast address of the network of the IPv4
+// address with the given mask.
+func broadcastAddr(ip net.IP, mask net.IPMask) net.IP {
+	ip = ip.To4()
+
+	if len(mask) == net.IPv6len {
+		mask = mask[12:]
+	}
+
+	broadcast := make(net.IP, net.IPv4len)
+
+	for i := range broadcast {
+		broadcast[i] = ip[i] | ^mask[i]
+	}
+
+	return broadcast
+}
+
+// interfaceAddr returns the first IPv4 address of the named interface.
+func interfaceAddr(name string) (net.IP, error) {
+	iface, err := net.InterfaceByName(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := iface.Addrs()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, addr := range addrs {
+		if network, ok := addr.(*net.IPNet); ok && network.IP.To4() != nil {
+			return network.IP.To4(), nil
+		}
+	}
+
+	return nil, fmt.Errorf("interface %s has no IPv4 address", name)
+}
+
+// ethernetFrame returns the payload within an Ethernet frame sent from the
+// given mac address to the broadcast mac address.
+func ethernetFrame(source net.HardwareAddr, payload []byte) []byte {
+	frame := make([]byte, 0, 14+len(payload))
+	frame = append(frame, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff)
+	frame = append(frame, source...)
+	frame = append(frame, byte(EtherType>>8), byte(EtherType&0xff))
+
+	return append(frame, payload...)
+}
@@ -0,0 +1,47 @@
+//go:build linux
+
+package wol
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+)
+
+// rawSender returns a function sending the payload as an Ethernet frame to the
+// broadcast mac address on the named interface.
+func rawSender(name string) (func([]byte) error, error) {
+	iface, err := net.InterfaceByName(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(iface.HardwareAddr) != 6 {
+		return nil, fmt.Errorf("interface %s has no EUI-48 mac address", name)
+	}
+
+	return func(payload []byte) error {
+		fd, err := syscall.Socket(syscall.AF_PACKET, syscall.SOCK_RAW, int(htons(EtherType)))
+
+		if err != nil {
+			return err
+		}
+
+		defer syscall.Close(fd)
+
+		broadcast := [8]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+		addr := &syscall.SockaddrLinklayer{
+			Protocol: htons(EtherType),
+			Ifindex:  iface.Index,
+			Halen:    6,
+			Addr:     broadcast,
+		}
+
+		return syscall.Sendto(fd, ethernetFrame(iface.HardwareAddr, payload), 0, addr)
+	}, nil
+}
+
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package wol
+
+import "errors"
+
+func rawSender(name string) (func([]byte) error, error) {
+	return nil, errors.New("raw ethernet frames are only supported on linux")
+}
@@ -2,7 +2,9 @@ package wol
 
 import (
 	"bytes"
+	"net"
 	"testing"
+	"time"
 )
 
 func TestNewMagicPacket(t *testing.T) {
@@ -42,3 +44,80 @@ func TestEui48(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestWithPassword(t *testing.T) {
+	packet, _ := NewMagicPacket("AA:AA:AA:AA:AA:AA")
+
+	payload, err := packet.WithPassword("01:02:03:04:05:06")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(payload) != 108 || !bytes.Equal(payload[102:], []byte{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("password not appended to packet: %v", payload[102:])
+	}
+
+	if _, err := packet.WithPassword("01:02:03:04"); err == nil {
+		t.Error("able to use a password which is not six bytes")
+	}
+}
+
+func TestBroadcastAddr(t *testing.T) {
+	cases := map[string]string{
+		"192.168.1.20/24": "192.168.1.255",
+		"10.20.30.40/16":  "10.20.255.255",
+		"172.16.5.9/28":   "172.16.5.15",
+	}
+
+	for cidr, expected := range cases {
+		ip, network, _ := net.ParseCIDR(cidr)
+
+		if got := broadcastAddr(ip, network.Mask).String(); got != expected {
+			t.Errorf("broadcast of %s is %s, expected %s", cidr, got, expected)
+		}
+	}
+}
+
+func TestEthernetFrame(t *testing.T) {
+	source := net.HardwareAddr{1, 2, 3, 4, 5, 6}
+	frame := ethernetFrame(source, []byte{9})
+
+	expected := []byte{255, 255, 255, 255, 255, 255, 1, 2, 3, 4, 5, 6, 0x08, 0x42, 9}
+	if !bytes.Equal(frame, expected) {
+		t.Errorf("unexpected frame %v", frame)
+	}
+}
+
+func TestWakeBurst(t *testing.T) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	err = Wake("AA:AA:AA:AA:AA:AA", Options{
+		Broadcast: "127.0.0.1",
+		Source:    "127.0.0.1",
+		Port:      conn.LocalAddr().(*net.UDPAddr).Port,
+		Count:     3,
+		Interval:  time.Millisecond,
+		Password:  "01:02:03:04:05:06",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 256)
+	for i := 0; i < 3; i++ {
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+
+		n, err := conn.Read(buf)
+		if err != nil {
+			t.Fatalf("packet %d not received: %v", i, err)
+		}
+
+		if n != 108 {
+			t.Errorf("packet %d is %d bytes, expected 108", i, n)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package device
 
+import "encoding/json"
+
 type Device interface {
 	// Methods
-	Init()
+	Init() error
 	PowerOff() error
 	PowerOn() error
 	List() error
@@ -24,11 +26,36 @@ type Device interface {
 }
 
 type DeviceInfo struct {
+	// ID uniquely identifies the device, being the UPnP UDN (duid) of the
+	// device when known. Tokens are stored against it.
+	ID    string `json:"id,omitempty"`
 	Name  string `json:"name"`
 	Mac   string `json:"mac"`
 	Ip    string `json:"ip"`
 	Type  string `json:"type"`
 	Token string `json:"token,omitempty"`
+	// Port is the port the TV was found to serve its api on, probed for
+	// when zero.
+	Port int `json:"port,omitempty"`
+	// CertFingerprint is the SHA-256 fingerprint of the certificate trusted
+	// for the device, recorded when pairing.
+	CertFingerprint string `json:"cert_fingerprint,omitempty"`
+	// Macros holds the named macros of the device, each being the JSON array
+	// of its steps.
+	Macros map[string]json.RawMessage `json:"macros,omitempty"`
+	// Apps caches the catalogue of applications of the device, see the apps
+	// package.
+	Apps json.RawMessage `json:"apps,omitempty"`
+}
+
+// Key returns the identifier credentials of the device are stored under, the
+// device id when known and the ip address otherwise.
+func (d DeviceInfo) Key() string {
+	if d.ID != "" {
+		return d.ID
+	}
+
+	return d.Ip
 }
 
 func Exists(devices []DeviceInfo, dev DeviceInfo) bool {
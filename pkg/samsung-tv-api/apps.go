@@ -0,0 +1,240 @@
+package samsung_tv_api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/apps"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/websocket"
+)
+
+// DefaultAppsTTL is how long the catalogue of applications is cached for when
+// AppsTTL is not set.
+const DefaultAppsTTL = 24 * time.Hour
+
+// Apps returns the catalogue of applications of the TV, the installed
+// applications merged with the well-known applications of the apps package.
+// The catalogue is cached in the Apps of the device for AppsTTL, the device
+// configuration must be persisted by the caller to keep it.
+func (s *SamsungTvClient) Apps(ctx context.Context) (apps.Catalogue, error) {
+	ttl := s.AppsTTL
+
+	if ttl <= 0 {
+		ttl = DefaultAppsTTL
+	}
+
+	if cached, ok := s.cachedApps(); ok && time.Since(cached.Fetched) < ttl {
+		return cached, nil
+	}
+
+	return s.RefreshApps(ctx)
+}
+
+// RefreshApps lists the installed applications again, replacing the cached
+// catalogue.
+func (s *SamsungTvClient) RefreshApps(ctx context.Context) (apps.Catalogue, error) {
+	resp, err := s.Websocket.GetApplicationsListContext(ctx)
+
+	if err != nil {
+		return apps.Catalogue{}, err
+	}
+
+	var installed []apps.App
+
+	for _, app := range resp.Data.Applications {
+		installed = append(installed, apps.App{ID: app.AppID, Name: app.Name, Type: app.AppType})
+	}
+
+	catalogue := apps.NewCatalogue(installed, time.Now())
+
+	raw, err := json.Marshal(catalogue)
+
+	if err != nil {
+		return catalogue, err
+	}
+
+	s.cfg.Apps = raw
+	return catalogue, nil
+}
+
+// cachedApps returns the catalogue cached in the device configuration.
+func (s *SamsungTvClient) cachedApps() (apps.Catalogue, bool) {
+	var catalogue apps.Catalogue
+
+	if len(s.cfg.Apps) == 0 || json.Unmarshal(s.cfg.Apps, &catalogue) != nil {
+		return catalogue, false
+	}
+
+	return catalogue, true
+}
+
+// catalogue returns the catalogue of applications, falling back to the cached
+// or well-known applications when the installed applications cannot be listed.
+func (s *SamsungTvClient) catalogue(ctx context.Context) apps.Catalogue {
+	catalogue, err := s.Apps(ctx)
+
+	if err == nil {
+		return catalogue
+	}
+
+	log.Printf("unable to list the installed applications, using known applications: %v", err)
+
+	if cached, ok := s.cachedApps(); ok {
+		return cached
+	}
+
+	return apps.NewCatalogue(nil, time.Time{})
+}
+
+// FindApp returns the application of the catalogue best matching the name,
+// see apps.Catalogue.Find. When the installed applications cannot be listed
+// the cached or well-known applications are searched.
+func (s *SamsungTvClient) FindApp(ctx context.Context, name string) (apps.App, error) {
+	return s.catalogue(ctx).Find(name)
+}
+
+// LaunchApp launches the application best matching the name, such as
+// "netflix", or with the id, waiting for it to become visible through the
+// AppManager. Applications of the catalogue are launched over the websocket
+// api with their action type, ids missing from the catalogue through the rest
+// api.
+func (s *SamsungTvClient) LaunchApp(ctx context.Context, name string) (apps.Result, error) {
+	app, err := s.FindApp(ctx, name)
+
+	if errors.Is(err, tverrors.ErrAppNotFound) && isAppID(name) {
+		return s.AppManager.Launch(ctx, name)
+	}
+
+	if err != nil {
+		return apps.Result{ID: name, Outcome: apps.NotInstalled}, err
+	}
+
+	log.Printf("Launching %s (%s)", app.Name, app.ID)
+
+	return s.AppManager.LaunchWith(ctx, app.ID, func(ctx context.Context) error {
+		return s.Websocket.RunApplicationContext(ctx, app.ID, app.ActionType(), "")
+	})
+}
+
+// CloseApp closes the application best matching the name or with the id
+// through the AppManager, waiting for it to stop running.
+func (s *SamsungTvClient) CloseApp(ctx context.Context, name string) (apps.Result, error) {
+	id, err := s.resolveAppID(ctx, name)
+
+	if err != nil {
+		return apps.Result{ID: name, Outcome: apps.NotInstalled}, err
+	}
+
+	return s.AppManager.Close(ctx, id)
+}
+
+// AppStatus returns the status of the application best matching the name or
+// with the id.
+func (s *SamsungTvClient) AppStatus(ctx context.Context, name string) (apps.Status, error) {
+	id, err := s.resolveAppID(ctx, name)
+
+	if err != nil {
+		return apps.Status{ID: name}, err
+	}
+
+	return s.AppManager.Status(ctx, id)
+}
+
+// WatchApps sends an AppChanged every time the application shown on the screen
+// changes until the context is done, see apps.Manager.Watch. Besides polling,
+// the applications are polled on every ed.* event read from the websocket, as
+// sent when an application is launched, so the connection should be set up
+// for changes to be noticed sooner. Any Trigger of the options is replaced.
+func (s *SamsungTvClient) WatchApps(ctx context.Context, opts apps.WatchOptions) <-chan apps.AppChanged {
+	events := s.Websocket.Subscribe(websocket.AllEvents)
+	trigger := make(chan struct{}, 1)
+
+	go func() {
+		defer s.Websocket.Unsubscribe(events)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev := <-events:
+				if !strings.HasPrefix(ev.Event, "ed.") {
+					continue
+				}
+
+				select {
+				case trigger <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	opts.Trigger = trigger
+	return s.AppManager.Watch(ctx, opts)
+}
+
+// resolveAppID returns the id of the application best matching the name,
+// names which look like an id being used as is when not in the catalogue.
+func (s *SamsungTvClient) resolveAppID(ctx context.Context, name string) (string, error) {
+	app, err := s.FindApp(ctx, name)
+
+	if errors.Is(err, tverrors.ErrAppNotFound) && isAppID(name) {
+		return name, nil
+	}
+
+	return app.ID, err
+}
+
+// appCandidates returns the ids of the applications of the cached catalogue,
+// installed applications first, or of the well-known applications. These are
+// the applications AppManager.ForegroundApp checks.
+func (s *SamsungTvClient) appCandidates() []string {
+	catalogue, ok := s.cachedApps()
+
+	if !ok {
+		catalogue = apps.NewCatalogue(nil, time.Time{})
+	}
+
+	ids := make([]string, 0, len(catalogue.Apps))
+
+	for _, app := range catalogue.Apps {
+		ids = append(ids, app.ID)
+	}
+
+	return ids
+}
+
+// isAppID returns true for names which look like an application id, such as
+// 3201907018807 or org.tizen.browser.
+func isAppID(name string) bool {
+	return name != "" && !strings.ContainsAny(name, " \t") && strings.ContainsAny(name, "0123456789.")
+}
+
+// OpenDeepLink launches the application of the deep link with its metaTag,
+// such as a link composed by apps.YouTube. The application is launched as a
+// DEEP_LINK or NATIVE_LAUNCH depending on the app_type reported by the TV, and
+// tverrors.ErrAppNotFound is returned when the TV listed its installed
+// applications without it.
+func (s *SamsungTvClient) OpenDeepLink(ctx context.Context, link apps.DeepLink) error {
+	catalogue := s.catalogue(ctx)
+	app, ok := catalogue.ByID(link.AppID)
+
+	// the browser is a system application, which is not listed as installed.
+	if !catalogue.Fetched.IsZero() && !app.Installed && link.AppID != apps.BrowserID {
+		return fmt.Errorf("%w: %s is not installed", tverrors.ErrAppNotFound, link.AppID)
+	}
+
+	if !ok {
+		app = apps.App{ID: link.AppID}
+	}
+
+	log.Printf("Opening %s in %s (%s)", link.MetaTag, app.Name, app.ID)
+
+	return s.Websocket.RunApplicationContext(ctx, app.ID, app.ActionType(), link.MetaTag)
+}
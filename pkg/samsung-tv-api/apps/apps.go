@@ -0,0 +1,201 @@
+package apps
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/internal/app/samsung-tv-api/fuzzy"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+)
+
+// This package holds the catalogue of applications of a TV, the applications
+// installed on the TV merged with a table of well-known Tizen applications, so
+// applications can be found by name rather than by id.
+
+const (
+	// TypeWebApp is the app_type of web applications, launched as a deep link.
+	TypeWebApp = 2
+	// TypeNative is the app_type of native applications such as the browser.
+	TypeNative = 4
+)
+
+// App is a single application of the catalogue.
+type App struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Type is the app_type reported by the TV, see TypeWebApp.
+	Type int `json:"type,omitempty"`
+	// Aliases are other names the application is known by.
+	Aliases []string `json:"aliases,omitempty"`
+	// Installed is true for applications reported as installed by the TV.
+	Installed bool `json:"installed,omitempty"`
+}
+
+// ActionType returns the action_type the application is launched with over
+// the websocket api, DEEP_LINK for web applications and NATIVE_LAUNCH
+// otherwise.
+func (a App) ActionType() string {
+	if a.Type == TypeWebApp {
+		return "DEEP_LINK"
+	}
+
+	return "NATIVE_LAUNCH"
+}
+
+// Known holds well-known Tizen applications, which can be launched by name
+// even when the list of installed applications is not available.
+var Known = []App{
+	{ID: NetflixID, Name: "Netflix", Type: TypeWebApp},
+	{ID: YouTubeID, Name: "YouTube", Type: TypeWebApp, Aliases: []string{"yt"}},
+	{ID: "3201707014489", Name: "YouTube TV", Type: TypeWebApp},
+	{ID: "3201910019365", Name: "Prime Video", Type: TypeWebApp, Aliases: []string{"Amazon Prime Video", "Amazon"}},
+	{ID: "3201901017640", Name: "Disney+", Type: TypeWebApp, Aliases: []string{"Disney Plus"}},
+	{ID: PlexID, Name: "Plex", Type: TypeWebApp},
+	{ID: JellyfinID, Name: "Jellyfin", Type: TypeWebApp},
+	{ID: "3201807016597", Name: "Apple TV", Type: TypeWebApp, Aliases: []string{"Apple TV+"}},
+	{ID: "3201908019041", Name: "Apple Music", Type: TypeWebApp},
+	{ID: "3201606009684", Name: "Spotify", Type: TypeWebApp},
+	{ID: "3201601007625", Name: "Hulu", Type: TypeWebApp},
+	{ID: "3201601007230", Name: "HBO Max", Type: TypeWebApp, Aliases: []string{"Max"}},
+	{ID: "3201504001965", Name: "Tubi", Type: TypeWebApp},
+	{ID: BrowserID, Name: "Browser", Type: TypeNative, Aliases: []string{"Internet", "Web Browser"}},
+}
+
+// Catalogue is the list of applications of a TV.
+type Catalogue struct {
+	Apps []App `json:"apps"`
+	// Fetched is when the installed applications were listed, zero when only
+	// the well-known applications are known.
+	Fetched time.Time `json:"fetched,omitempty"`
+}
+
+// NewCatalogue merges the installed applications with the well-known
+// applications, installed applications taking precedence and keeping the
+// aliases of the matching well-known application.
+func NewCatalogue(installed []App, fetched time.Time) Catalogue {
+	c := Catalogue{Fetched: fetched}
+	byID := map[string]int{}
+
+	for _, app := range installed {
+		app.Installed = true
+		byID[app.ID] = len(c.Apps)
+		c.Apps = append(c.Apps, app)
+	}
+
+	for _, known := range Known {
+		if i, ok := byID[known.ID]; ok {
+			c.Apps[i].Aliases = known.Aliases
+
+			if c.Apps[i].Type == 0 {
+				c.Apps[i].Type = known.Type
+			}
+
+			continue
+		}
+
+		known.Aliases = append([]string(nil), known.Aliases...)
+		c.Apps = append(c.Apps, known)
+	}
+
+	return c
+}
+
+// ByID returns the application with the id.
+func (c Catalogue) ByID(id string) (App, bool) {
+	for _, app := range c.Apps {
+		if app.ID == id {
+			return app, true
+		}
+	}
+
+	return App{}, false
+}
+
+// Find returns the application best matching the name, which is either the
+// id or the name of the application, ignoring case, spaces and punctuation and
+// allowing small typos. Installed applications are preferred, and
+// tverrors.ErrAppNotFound is returned when nothing or more than one
+// application matches.
+func (c Catalogue) Find(name string) (App, error) {
+	matches := c.Search(name)
+
+	if len(matches) == 0 {
+		return App{}, fmt.Errorf("%w: %s", tverrors.ErrAppNotFound, name)
+	}
+
+	best := matches[0]
+	var names []string
+
+	for _, app := range matches {
+		if score(app, name) == score(best, name) && app.Installed == best.Installed {
+			names = append(names, app.Name)
+		}
+	}
+
+	if len(names) > 1 {
+		return App{}, fmt.Errorf("%w: %s, did you mean %s", tverrors.ErrAppNotFound, name, strings.Join(names, " or "))
+	}
+
+	return best, nil
+}
+
+// Search returns the applications matching the query, best matches first. An
+// empty query matches every application.
+func (c Catalogue) Search(query string) []App {
+	var matches []App
+
+	for _, app := range c.Apps {
+		if score(app, query) < noMatch {
+			matches = append(matches, app)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		si, sj := score(matches[i], query), score(matches[j], query)
+
+		if si != sj {
+			return si < sj
+		}
+
+		return matches[i].Installed && !matches[j].Installed
+	})
+
+	return matches
+}
+
+// noMatch is the score of an application not matching a query.
+const noMatch = 100
+
+// score ranks how well the application matches the query, lower being better:
+// 0 for the id or a name, 1 for a name starting with the query, 2 for a name
+// containing it and 3 or more for a name within a few typos of it.
+func score(app App, query string) int {
+	wanted := fuzzy.Normalize(query)
+
+	if wanted == "" || app.ID == query {
+		return 0
+	}
+
+	best := noMatch
+
+	for _, name := range append([]string{app.Name}, app.Aliases...) {
+		candidate := fuzzy.Normalize(name)
+
+		switch {
+		case candidate == wanted:
+			return 0
+		case strings.HasPrefix(candidate, wanted):
+			best = min(best, 1)
+		case strings.Contains(candidate, wanted):
+			best = min(best, 2)
+		case len(wanted) >= 4:
+			if d := fuzzy.Distance(wanted, candidate); d <= len(wanted)/4 {
+				best = min(best, 2+d)
+			}
+		}
+	}
+
+	return best
+}
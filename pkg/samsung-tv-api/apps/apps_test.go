@@ -0,0 +1,81 @@
+package apps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestCatalogue() Catalogue {
+	return NewCatalogue([]App{
+		{ID: "3201907018807", Name: "Netflix", Type: TypeWebApp},
+		{ID: "3201710015037", Name: "Gallery", Type: TypeWebApp},
+	}, time.Now())
+}
+
+func TestNewCatalogueMergesKnownApps(t *testing.T) {
+	c := getTestCatalogue()
+
+	assert.Equal(t, App{ID: "3201907018807", Name: "Netflix", Type: TypeWebApp, Installed: true}, c.Apps[0])
+	assert.True(t, c.Apps[1].Installed)
+	assert.Len(t, c.Apps, len(Known)+1)
+
+	for _, app := range c.Apps[2:] {
+		assert.False(t, app.Installed, app.Name)
+		assert.NotEqual(t, "Netflix", app.Name)
+	}
+}
+
+func TestFind(t *testing.T) {
+	c := getTestCatalogue()
+
+	for name, id := range map[string]string{
+		"netflix":           "3201907018807",
+		"NETFLX":            "3201907018807",
+		"3201907018807":     "3201907018807",
+		"gall":              "3201710015037",
+		"disney plus":       "3201901017640",
+		"Disney+":           "3201901017640",
+		"amazon":            "3201910019365",
+		"youtube":           "111299001912",
+		"internet":          "org.tizen.browser",
+		"org.tizen.browser": "org.tizen.browser",
+	} {
+		app, err := c.Find(name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, id, app.ID, name)
+	}
+}
+
+func TestFindNotFound(t *testing.T) {
+	c := getTestCatalogue()
+
+	_, err := c.Find("solitaire")
+	assert.ErrorIs(t, err, tverrors.ErrAppNotFound)
+
+	_, err = c.Find("apple")
+	assert.ErrorIs(t, err, tverrors.ErrAppNotFound)
+	assert.Contains(t, err.Error(), "Apple TV or Apple Music")
+}
+
+func TestSearch(t *testing.T) {
+	c := getTestCatalogue()
+
+	assert.Len(t, c.Search(""), len(c.Apps))
+
+	var names []string
+	for _, app := range c.Search("you") {
+		names = append(names, app.Name)
+	}
+	assert.Equal(t, []string{"YouTube", "YouTube TV"}, names)
+}
+
+func TestActionType(t *testing.T) {
+	assert.Equal(t, "DEEP_LINK", App{Type: TypeWebApp}.ActionType())
+	assert.Equal(t, "NATIVE_LAUNCH", App{Type: TypeNative}.ActionType())
+	assert.Equal(t, "NATIVE_LAUNCH", App{}.ActionType())
+	assert.Equal(t, "NATIVE_LAUNCH", App{Type: 1}.ActionType())
+	assert.Equal(t, "NATIVE_LAUNCH", App{Type: 3}.ActionType())
+}
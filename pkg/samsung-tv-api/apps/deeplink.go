@@ -0,0 +1,109 @@
+package apps
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Ids of the applications deep links are composed for.
+const (
+	NetflixID  = "3201907018807"
+	YouTubeID  = "111299001912"
+	PlexID     = "3201512006963"
+	JellyfinID = "AprZAARz4r.Jellyfin"
+	BrowserID  = "org.tizen.browser"
+)
+
+// ErrInvalidDeepLink is returned when a deep link cannot be composed from the
+// provided values.
+var ErrInvalidDeepLink = errors.New("invalid deep link")
+
+// DeepLink opens content within an application, the MetaTag being handed to
+// the application when launched.
+type DeepLink struct {
+	AppID   string
+	MetaTag string
+}
+
+var (
+	youTubeVideoID = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	netflixTitleID = regexp.MustCompile(`^[0-9]+$`)
+	plexID         = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+	jellyfinItemID = regexp.MustCompile(`^[A-Fa-f0-9-]{32,36}$`)
+)
+
+// YouTube returns a deep link playing the video, provided either as the 11
+// character video id or as a youtube.com or youtu.be url.
+func YouTube(video string) (DeepLink, error) {
+	id := video
+
+	if u, err := url.Parse(video); err == nil && u.Host != "" {
+		switch strings.TrimPrefix(u.Host, "www.") {
+		case "youtu.be":
+			id = strings.TrimPrefix(u.Path, "/")
+		case "youtube.com", "m.youtube.com":
+			id = u.Query().Get("v")
+		}
+	}
+
+	if !youTubeVideoID.MatchString(id) {
+		return DeepLink{}, fmt.Errorf("%w: %q is not a youtube video", ErrInvalidDeepLink, video)
+	}
+
+	return DeepLink{AppID: YouTubeID, MetaTag: "v=" + id}, nil
+}
+
+// Netflix returns a deep link opening the title with the numeric id, as found
+// in netflix.com/title/<id> urls.
+func Netflix(titleID string) (DeepLink, error) {
+	if !netflixTitleID.MatchString(titleID) {
+		return DeepLink{}, fmt.Errorf("%w: %q is not a netflix title id", ErrInvalidDeepLink, titleID)
+	}
+
+	return DeepLink{AppID: NetflixID, MetaTag: "m=" + titleID}, nil
+}
+
+// Plex returns a deep link opening the item with the rating key on the server
+// with the machine identifier.
+func Plex(machineID, ratingKey string) (DeepLink, error) {
+	if !plexID.MatchString(machineID) || !plexID.MatchString(ratingKey) {
+		return DeepLink{}, fmt.Errorf("%w: %q on %q is not a plex item", ErrInvalidDeepLink, ratingKey, machineID)
+	}
+
+	tag := fmt.Sprintf("https://app.plex.tv/desktop#!/server/%s/details?key=%s",
+		machineID, url.QueryEscape("/library/metadata/"+ratingKey))
+
+	return DeepLink{AppID: PlexID, MetaTag: tag}, nil
+}
+
+// Jellyfin returns a deep link opening the item with the id on the server,
+// provided as the base url of the server such as http://jellyfin.lan:8096.
+func Jellyfin(server, itemID string) (DeepLink, error) {
+	u, err := url.Parse(server)
+
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return DeepLink{}, fmt.Errorf("%w: %q is not a jellyfin server url", ErrInvalidDeepLink, server)
+	}
+
+	if !jellyfinItemID.MatchString(itemID) {
+		return DeepLink{}, fmt.Errorf("%w: %q is not a jellyfin item id", ErrInvalidDeepLink, itemID)
+	}
+
+	tag := fmt.Sprintf("%s/web/index.html#!/details?id=%s", strings.TrimSuffix(server, "/"), itemID)
+
+	return DeepLink{AppID: JellyfinID, MetaTag: tag}, nil
+}
+
+// Browser returns a deep link opening the http or https url in the browser.
+func Browser(link string) (DeepLink, error) {
+	u, err := url.Parse(link)
+
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return DeepLink{}, fmt.Errorf("%w: %q is not a http url", ErrInvalidDeepLink, link)
+	}
+
+	return DeepLink{AppID: BrowserID, MetaTag: link}, nil
+}
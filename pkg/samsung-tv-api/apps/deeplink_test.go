@@ -0,0 +1,61 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYouTube(t *testing.T) {
+	for _, video := range []string{
+		"dQw4w9WgXcQ",
+		"https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42",
+		"https://youtu.be/dQw4w9WgXcQ",
+	} {
+		link, err := YouTube(video)
+		assert.NoError(t, err, video)
+		assert.Equal(t, DeepLink{AppID: YouTubeID, MetaTag: "v=dQw4w9WgXcQ"}, link, video)
+	}
+
+	_, err := YouTube("https://vimeo.com/123")
+	assert.ErrorIs(t, err, ErrInvalidDeepLink)
+}
+
+func TestNetflix(t *testing.T) {
+	link, err := Netflix("80100172")
+	assert.NoError(t, err)
+	assert.Equal(t, DeepLink{AppID: NetflixID, MetaTag: "m=80100172"}, link)
+
+	_, err = Netflix("stranger things")
+	assert.ErrorIs(t, err, ErrInvalidDeepLink)
+}
+
+func TestPlex(t *testing.T) {
+	link, err := Plex("a1b2c3", "1234")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://app.plex.tv/desktop#!/server/a1b2c3/details?key=%2Flibrary%2Fmetadata%2F1234", link.MetaTag)
+
+	_, err = Plex("a1b2c3", "../1234")
+	assert.ErrorIs(t, err, ErrInvalidDeepLink)
+}
+
+func TestJellyfin(t *testing.T) {
+	link, err := Jellyfin("http://jellyfin.lan:8096/", "0b2d6e1f7e0c4a3b9b4e1f7e0c4a3b9b")
+	assert.NoError(t, err)
+	assert.Equal(t, DeepLink{AppID: JellyfinID, MetaTag: "http://jellyfin.lan:8096/web/index.html#!/details?id=0b2d6e1f7e0c4a3b9b4e1f7e0c4a3b9b"}, link)
+
+	_, err = Jellyfin("jellyfin.lan", "0b2d6e1f7e0c4a3b9b4e1f7e0c4a3b9b")
+	assert.ErrorIs(t, err, ErrInvalidDeepLink)
+
+	_, err = Jellyfin("http://jellyfin.lan:8096", "123")
+	assert.ErrorIs(t, err, ErrInvalidDeepLink)
+}
+
+func TestBrowser(t *testing.T) {
+	link, err := Browser("https://example.com/a?b=c")
+	assert.NoError(t, err)
+	assert.Equal(t, DeepLink{AppID: BrowserID, MetaTag: "https://example.com/a?b=c"}, link)
+
+	_, err = Browser("javascript:alert(1)")
+	assert.ErrorIs(t, err, ErrInvalidDeepLink)
+}
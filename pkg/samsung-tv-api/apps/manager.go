@@ -0,0 +1,357 @@
+package apps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	samsung_http "github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/http"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+)
+
+// Rest is the part of the rest api the Manager drives applications through,
+// implemented by http.SamsungRestClient.
+type Rest interface {
+	GetApplicationStatusContext(ctx context.Context, appId string) (samsung_http.ApplicationResponse, error)
+	RunApplicationContext(ctx context.Context, appId string) (interface{}, error)
+	CloseApplicationContext(ctx context.Context, appId string) (interface{}, error)
+}
+
+// LifecyclePolicy configures how the Manager waits for applications to start
+// and close. Zero values are replaced with the defaults below.
+type LifecyclePolicy struct {
+	// Timeout bounds Launch and Close when the context has no deadline.
+	// Defaults to 30s.
+	Timeout time.Duration
+	// Attempts is the number of times an application is launched before
+	// giving up. Defaults to 2.
+	Attempts int
+	// RetryInterval is the time waited for a launched application to become
+	// visible before launching it again. Defaults to 10s.
+	RetryInterval time.Duration
+	// PollInterval is the time between reading the status of an application.
+	// Defaults to 500ms.
+	PollInterval time.Duration
+}
+
+func (p LifecyclePolicy) withDefaults() LifecyclePolicy {
+	if p.Timeout <= 0 {
+		p.Timeout = 30 * time.Second
+	}
+
+	if p.Attempts <= 0 {
+		p.Attempts = 2
+	}
+
+	if p.RetryInterval <= 0 {
+		p.RetryInterval = 10 * time.Second
+	}
+
+	if p.PollInterval <= 0 {
+		p.PollInterval = 500 * time.Millisecond
+	}
+
+	return p
+}
+
+// Status is the state of an application as reported by the TV.
+type Status struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Running bool   `json:"running"`
+	// Visible is true when the application is shown on the screen.
+	Visible bool   `json:"visible"`
+	Version string `json:"version"`
+}
+
+// Outcome describes what Launch or Close did.
+type Outcome string
+
+const (
+	// Launched is the outcome of an application launched and shown.
+	Launched Outcome = "launched"
+	// AlreadyRunning is the outcome of launching an application which was
+	// already shown, which is left as is.
+	AlreadyRunning Outcome = "already running"
+	// Closed is the outcome of an application closed.
+	Closed Outcome = "closed"
+	// NotRunning is the outcome of closing an application which was not
+	// running.
+	NotRunning Outcome = "not running"
+	// NotInstalled is the outcome of an application the TV does not know.
+	NotInstalled Outcome = "not installed"
+	// Failed is the outcome of an application which did not reach the
+	// requested state, the error telling why.
+	Failed Outcome = "failed"
+)
+
+// Result is the result of Launch or Close.
+type Result struct {
+	ID      string
+	Outcome Outcome
+	// Status is the last status read of the application.
+	Status Status
+	// Attempts is the number of times the application was launched or
+	// closed.
+	Attempts int
+}
+
+// Manager launches and closes applications through the rest api, confirming
+// the application reached the requested state by polling its status.
+type Manager struct {
+	Rest Rest
+	// Policy configures the retries and polling of the Manager.
+	Policy LifecyclePolicy
+	// Candidates returns the ids of the applications ForegroundApp checks, the
+	// well-known applications when nil.
+	Candidates func() []string
+}
+
+// Status returns the status of the application, tverrors.ErrAppNotFound being
+// returned when it is not installed.
+func (m *Manager) Status(ctx context.Context, id string) (Status, error) {
+	resp, err := m.Rest.GetApplicationStatusContext(ctx, id)
+
+	if err != nil {
+		return Status{ID: id}, err
+	}
+
+	return Status{
+		ID:      id,
+		Name:    resp.Name,
+		Running: resp.Running,
+		Visible: resp.Visible,
+		Version: resp.Version,
+	}, nil
+}
+
+// Launch launches the application and waits for it to become visible,
+// launching it again every RetryInterval up to Attempts times. An application
+// already visible is not launched again.
+//
+// The error is nil only for the Launched and AlreadyRunning outcomes, a
+// failed launch returning an error matching tverrors.ErrAppTimeout and an
+// unknown application one matching tverrors.ErrAppNotFound.
+func (m *Manager) Launch(ctx context.Context, id string) (Result, error) {
+	return m.LaunchWith(ctx, id, func(ctx context.Context) error {
+		_, err := m.Rest.RunApplicationContext(ctx, id)
+		return err
+	})
+}
+
+// LaunchWith is Launch starting the application with the launch function
+// rather than the rest api, such as over the websocket api with the action
+// type of the application.
+func (m *Manager) LaunchWith(ctx context.Context, id string, launch func(context.Context) error) (Result, error) {
+	policy := m.Policy.withDefaults()
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		defer cancel()
+	}
+
+	result := Result{ID: id}
+	status, err := m.Status(ctx, id)
+	result.Status = status
+
+	if err != nil {
+		return m.failed(result, err)
+	}
+
+	if status.Visible {
+		result.Outcome = AlreadyRunning
+		return result, nil
+	}
+
+	for result.Attempts < policy.Attempts {
+		result.Attempts++
+
+		log.Printf("Launching %s, attempt %d of %d\n", id, result.Attempts, policy.Attempts)
+
+		if err := launch(ctx); err != nil {
+			return m.failed(result, err)
+		}
+
+		status, done, err := m.poll(ctx, id, policy.RetryInterval, visible)
+		result.Status = status
+
+		if err != nil {
+			return m.failed(result, err)
+		}
+
+		if done {
+			result.Outcome = Launched
+			return result, nil
+		}
+	}
+
+	return m.failed(result, fmt.Errorf("%w: %s not visible after %d attempts", tverrors.ErrAppTimeout, id, result.Attempts))
+}
+
+// Close closes the application and waits for it to stop running. An
+// application which is not running is not closed.
+//
+// The error is nil only for the Closed and NotRunning outcomes, see Launch.
+func (m *Manager) Close(ctx context.Context, id string) (Result, error) {
+	policy := m.Policy.withDefaults()
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		defer cancel()
+	}
+
+	result := Result{ID: id}
+	status, err := m.Status(ctx, id)
+	result.Status = status
+
+	if err != nil {
+		return m.failed(result, err)
+	}
+
+	if !status.Running {
+		result.Outcome = NotRunning
+		return result, nil
+	}
+
+	result.Attempts++
+
+	log.Printf("Closing %s\n", id)
+
+	if _, err := m.Rest.CloseApplicationContext(ctx, id); err != nil {
+		return m.failed(result, err)
+	}
+
+	status, err = m.WaitUntilClosed(ctx, id)
+	result.Status = status
+
+	if err != nil {
+		return m.failed(result, err)
+	}
+
+	result.Outcome = Closed
+	return result, nil
+}
+
+// WaitUntilVisible polls the status of the application until it is visible,
+// returning an error matching tverrors.ErrAppTimeout when the context is done
+// first.
+func (m *Manager) WaitUntilVisible(ctx context.Context, id string) (Status, error) {
+	status, _, err := m.poll(ctx, id, 0, visible)
+	return status, err
+}
+
+// WaitUntilClosed polls the status of the application until it is no longer
+// running, returning an error matching tverrors.ErrAppTimeout when the context
+// is done first.
+func (m *Manager) WaitUntilClosed(ctx context.Context, id string) (Status, error) {
+	status, _, err := m.poll(ctx, id, 0, func(s Status) bool { return !s.Running })
+	return status, err
+}
+
+// ForegroundApp returns the status of the candidate application shown on the
+// screen. The TV cannot be asked which application is shown, so the status of
+// every candidate is read until a visible one is found, and
+// tverrors.ErrAppNotFound is returned when none is.
+func (m *Manager) ForegroundApp(ctx context.Context) (Status, error) {
+	for _, id := range m.candidates() {
+		status, err := m.Status(ctx, id)
+
+		if ctx.Err() != nil {
+			return Status{}, ctx.Err()
+		}
+
+		if errors.Is(err, tverrors.ErrAppNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return Status{}, err
+		}
+
+		if status.Visible {
+			return status, nil
+		}
+	}
+
+	return Status{}, fmt.Errorf("%w: no candidate application is visible", tverrors.ErrAppNotFound)
+}
+
+func (m *Manager) candidates() []string {
+	if m.Candidates != nil {
+		return m.Candidates()
+	}
+
+	ids := make([]string, 0, len(Known))
+
+	for _, app := range Known {
+		ids = append(ids, app.ID)
+	}
+
+	return ids
+}
+
+// poll reads the status of the application every PollInterval until done
+// returns true, the limit passes or the context is done. A limit of zero waits
+// for the context only.
+func (m *Manager) poll(ctx context.Context, id string, limit time.Duration, done func(Status) bool) (Status, bool, error) {
+	policy := m.Policy.withDefaults()
+
+	var expired <-chan time.Time
+
+	if limit > 0 {
+		timer := time.NewTimer(limit)
+		defer timer.Stop()
+
+		expired = timer.C
+	}
+
+	ticker := time.NewTicker(policy.PollInterval)
+	defer ticker.Stop()
+
+	status := Status{ID: id}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status, false, errors.Join(tverrors.ErrAppTimeout, ctx.Err())
+		case <-expired:
+			return status, false, nil
+		case <-ticker.C:
+		}
+
+		current, err := m.Status(ctx, id)
+
+		if ctx.Err() != nil {
+			return status, false, errors.Join(tverrors.ErrAppTimeout, ctx.Err())
+		}
+
+		if err != nil {
+			return status, false, err
+		}
+
+		status = current
+
+		if done(status) {
+			return status, true, nil
+		}
+	}
+}
+
+// failed completes the result with the outcome of the error.
+func (m *Manager) failed(result Result, err error) (Result, error) {
+	result.Outcome = Failed
+
+	if errors.Is(err, tverrors.ErrAppNotFound) {
+		result.Outcome = NotInstalled
+	}
+
+	return result, err
+}
+
+func visible(s Status) bool {
+	return s.Visible
+}
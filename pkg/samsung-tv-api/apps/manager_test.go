@@ -0,0 +1,149 @@
+package apps
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	samsung_http "github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/http"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/samsungtvtest"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestManager(t *testing.T) (*Manager, *samsungtvtest.Server) {
+	tv := samsungtvtest.NewServer()
+	t.Cleanup(tv.Close)
+
+	tv.AddApp(samsungtvtest.App{ID: NetflixID, Name: "Netflix", AppType: TypeWebApp})
+	tv.AddApp(samsungtvtest.App{ID: YouTubeID, Name: "YouTube", AppType: TypeWebApp})
+
+	return &Manager{
+		Rest: &samsung_http.SamsungRestClient{BaseUrl: tv.RestBaseUrl, Timeout: time.Second},
+		Policy: LifecyclePolicy{
+			Timeout:       time.Second,
+			RetryInterval: 100 * time.Millisecond,
+			PollInterval:  10 * time.Millisecond,
+		},
+	}, tv
+}
+
+func TestManagerLaunch(t *testing.T) {
+	m, tv := getTestManager(t)
+	ctx := context.Background()
+
+	result, err := m.Launch(ctx, NetflixID)
+	assert.NoError(t, err)
+	assert.Equal(t, Launched, result.Outcome)
+	assert.Equal(t, 1, result.Attempts)
+	assert.True(t, result.Status.Visible)
+
+	app, _ := tv.App(NetflixID)
+	assert.True(t, app.Visible)
+
+	result, err = m.Launch(ctx, NetflixID)
+	assert.NoError(t, err)
+	assert.Equal(t, AlreadyRunning, result.Outcome)
+	assert.Equal(t, 0, result.Attempts)
+}
+
+func TestManagerLaunchWith(t *testing.T) {
+	m, tv := getTestManager(t)
+	ctx := context.Background()
+
+	launches := 0
+	result, err := m.LaunchWith(ctx, YouTubeID, func(ctx context.Context) error {
+		launches++
+		_, err := m.Rest.RunApplicationContext(ctx, YouTubeID)
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, Launched, result.Outcome)
+	assert.Equal(t, 1, launches)
+
+	app, _ := tv.App(YouTubeID)
+	assert.True(t, app.Visible)
+
+	failed := errors.New("unable to send")
+	result, err = m.LaunchWith(ctx, NetflixID, func(ctx context.Context) error { return failed })
+	assert.ErrorIs(t, err, failed)
+	assert.Equal(t, Failed, result.Outcome)
+	assert.Equal(t, 1, result.Attempts)
+}
+
+func TestManagerLaunchNotInstalled(t *testing.T) {
+	m, _ := getTestManager(t)
+
+	result, err := m.Launch(context.Background(), "3201699999999")
+	assert.ErrorIs(t, err, tverrors.ErrAppNotFound)
+	assert.Equal(t, NotInstalled, result.Outcome)
+}
+
+func TestManagerLaunchFailed(t *testing.T) {
+	m, tv := getTestManager(t)
+	tv.AddApp(samsungtvtest.App{ID: PlexID, Name: "Plex", AppType: TypeWebApp, Hidden: true})
+
+	result, err := m.Launch(context.Background(), PlexID)
+	assert.ErrorIs(t, err, tverrors.ErrAppTimeout)
+	assert.Equal(t, Failed, result.Outcome)
+	assert.Equal(t, 2, result.Attempts)
+	assert.True(t, result.Status.Running)
+	assert.False(t, result.Status.Visible)
+}
+
+func TestManagerClose(t *testing.T) {
+	m, tv := getTestManager(t)
+	ctx := context.Background()
+
+	result, err := m.Close(ctx, YouTubeID)
+	assert.NoError(t, err)
+	assert.Equal(t, NotRunning, result.Outcome)
+
+	_, err = m.Launch(ctx, YouTubeID)
+	assert.NoError(t, err)
+
+	result, err = m.Close(ctx, YouTubeID)
+	assert.NoError(t, err)
+	assert.Equal(t, Closed, result.Outcome)
+	assert.False(t, result.Status.Running)
+
+	app, _ := tv.App(YouTubeID)
+	assert.False(t, app.Running)
+
+	result, err = m.Close(ctx, "3201699999999")
+	assert.ErrorIs(t, err, tverrors.ErrAppNotFound)
+	assert.Equal(t, NotInstalled, result.Outcome)
+}
+
+func TestManagerWaitUntilVisibleTimesOut(t *testing.T) {
+	m, _ := getTestManager(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	status, err := m.WaitUntilVisible(ctx, NetflixID)
+	assert.ErrorIs(t, err, tverrors.ErrAppTimeout)
+	assert.False(t, status.Visible)
+}
+
+func TestManagerForegroundApp(t *testing.T) {
+	m, _ := getTestManager(t)
+	ctx := context.Background()
+
+	_, err := m.ForegroundApp(ctx)
+	assert.ErrorIs(t, err, tverrors.ErrAppNotFound)
+
+	_, err = m.Launch(ctx, YouTubeID)
+	assert.NoError(t, err)
+
+	status, err := m.ForegroundApp(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, YouTubeID, status.ID)
+	assert.Equal(t, "YouTube", status.Name)
+
+	m.Candidates = func() []string { return []string{NetflixID} }
+
+	_, err = m.ForegroundApp(ctx)
+	assert.ErrorIs(t, err, tverrors.ErrAppNotFound)
+}
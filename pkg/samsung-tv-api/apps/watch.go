@@ -0,0 +1,120 @@
+package apps
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+)
+
+// DefaultWatchInterval is the time between polls of Watch when the interval
+// of the WatchOptions is not set.
+const DefaultWatchInterval = 2 * time.Second
+
+// AppChanged is sent by Watch when the application shown on the screen
+// changes. From or To has an empty ID when no watched application is visible,
+// such as while watching live TV or browsing the home screen.
+type AppChanged struct {
+	From Status    `json:"from"`
+	To   Status    `json:"to"`
+	At   time.Time `json:"at"`
+}
+
+// WatchOptions configures Watch.
+type WatchOptions struct {
+	// Interval is the time between polls of the watched applications,
+	// DefaultWatchInterval when zero.
+	Interval time.Duration
+	// Apps are the ids of the watched applications, the Candidates of the
+	// Manager when empty.
+	Apps []string
+	// Trigger polls the watched applications as soon as it receives, for TVs
+	// pushing events when the shown application changes.
+	Trigger <-chan struct{}
+}
+
+// Watch polls which of the watched applications is shown on the screen,
+// sending an AppChanged every time it changes until the context is done, when
+// the channel is closed. The application shown when watching starts is taken
+// as the initial state and not sent.
+//
+// The TV cannot be asked which application is shown, only whether a given
+// application is visible, so applications which are not watched are reported
+// as no application being visible.
+func (m *Manager) Watch(ctx context.Context, opts WatchOptions) <-chan AppChanged {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultWatchInterval
+	}
+
+	watched := *m
+
+	if len(opts.Apps) > 0 {
+		watched.Candidates = func() []string { return opts.Apps }
+	}
+
+	changes := make(chan AppChanged)
+
+	go func() {
+		defer close(changes)
+
+		ticker := time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+
+		current, ok := watched.foreground(ctx)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-opts.Trigger:
+			}
+
+			next, polled := watched.foreground(ctx)
+
+			if !polled {
+				continue
+			}
+
+			// the first successful poll is the initial state.
+			if !ok || next.ID == current.ID {
+				current, ok = next, true
+				continue
+			}
+
+			change := AppChanged{From: current, To: next, At: time.Now()}
+			current = next
+
+			select {
+			case changes <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes
+}
+
+// foreground returns the application shown on the screen, a zero Status when
+// none of the candidates is visible. False is returned when the TV could not
+// be polled.
+func (m *Manager) foreground(ctx context.Context) (Status, bool) {
+	status, err := m.ForegroundApp(ctx)
+
+	if errors.Is(err, tverrors.ErrAppNotFound) {
+		return Status{}, true
+	}
+
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("unable to poll the shown application: %v\n", err)
+		}
+
+		return Status{}, false
+	}
+
+	return status, true
+}
@@ -0,0 +1,87 @@
+package apps
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func receiveChange(t *testing.T, changes <-chan AppChanged) AppChanged {
+	t.Helper()
+
+	select {
+	case change := <-changes:
+		return change
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the application to change")
+		return AppChanged{}
+	}
+}
+
+func TestManagerWatch(t *testing.T) {
+	m, _ := getTestManager(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := m.Watch(ctx, WatchOptions{Interval: 10 * time.Millisecond})
+
+	// the initial state is taken before anything is launched.
+	time.Sleep(50 * time.Millisecond)
+
+	_, err := m.Launch(ctx, NetflixID)
+	assert.NoError(t, err)
+
+	change := receiveChange(t, changes)
+	assert.Equal(t, "", change.From.ID)
+	assert.Equal(t, NetflixID, change.To.ID)
+	assert.False(t, change.At.IsZero())
+
+	_, err = m.Launch(ctx, YouTubeID)
+	assert.NoError(t, err)
+
+	change = receiveChange(t, changes)
+	assert.Equal(t, NetflixID, change.From.ID)
+	assert.Equal(t, YouTubeID, change.To.ID)
+
+	_, err = m.Close(ctx, YouTubeID)
+	assert.NoError(t, err)
+
+	change = receiveChange(t, changes)
+	assert.Equal(t, YouTubeID, change.From.ID)
+	assert.Equal(t, "", change.To.ID)
+
+	cancel()
+
+	assert.Eventually(t, func() bool {
+		_, open := <-changes
+		return !open
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestManagerWatchTrigger(t *testing.T) {
+	m, _ := getTestManager(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	trigger := make(chan struct{})
+	changes := m.Watch(ctx, WatchOptions{Interval: time.Hour, Apps: []string{YouTubeID}, Trigger: trigger})
+
+	_, err := m.Launch(ctx, NetflixID)
+	assert.NoError(t, err)
+
+	trigger <- struct{}{}
+
+	// netflix is not watched, so nothing changed.
+	_, err = m.Launch(ctx, YouTubeID)
+	assert.NoError(t, err)
+
+	trigger <- struct{}{}
+
+	change := receiveChange(t, changes)
+	assert.Equal(t, "", change.From.ID)
+	assert.Equal(t, YouTubeID, change.To.ID)
+}
@@ -0,0 +1,212 @@
+package samsung_tv_api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/apps"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/samsungtvtest"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/store"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLaunchApp(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+	client.TokenStore = store.NewMemoryStore()
+	ctx := context.Background()
+
+	tv.AddApp(samsungtvtest.App{ID: "3201907018807", Name: "Netflix", AppType: 2})
+	tv.AddApp(samsungtvtest.App{ID: "3201512006963", Name: "Plex", AppType: 2, Hidden: true})
+
+	assert.NoError(t, client.ConnectionSetup())
+	defer client.Disconnect()
+
+	// applications of the catalogue are launched over the websocket api and
+	// confirmed visible through the rest api.
+	result, err := client.LaunchApp(ctx, "netflx")
+	assert.NoError(t, err)
+	assert.Equal(t, "3201907018807", result.ID)
+	assert.Equal(t, apps.Launched, result.Outcome)
+
+	app, _ := tv.App("3201907018807")
+	assert.True(t, app.Visible)
+	assert.Equal(t, "DEEP_LINK", tv.Launches()[0].ActionType)
+
+	result, err = client.LaunchApp(ctx, "netflix")
+	assert.NoError(t, err)
+	assert.Equal(t, apps.AlreadyRunning, result.Outcome)
+
+	client.AppManager.Policy.RetryInterval = 50 * time.Millisecond
+
+	result, err = client.LaunchApp(ctx, "plex")
+	assert.ErrorIs(t, err, tverrors.ErrAppTimeout)
+	assert.Equal(t, apps.Failed, result.Outcome)
+	assert.Equal(t, 2, result.Attempts)
+
+	result, err = client.LaunchApp(ctx, "solitaire")
+	assert.ErrorIs(t, err, tverrors.ErrAppNotFound)
+	assert.Equal(t, apps.NotInstalled, result.Outcome)
+}
+
+func TestLaunchAppByID(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+	client.TokenStore = store.NewMemoryStore()
+	ctx := context.Background()
+
+	assert.NoError(t, client.ConnectionSetup())
+	defer client.Disconnect()
+
+	// the catalogue is cached, so the application installed afterwards is
+	// only known by its id and launched through the rest api.
+	_, err := client.Apps(ctx)
+	assert.NoError(t, err)
+
+	tv.AddApp(samsungtvtest.App{ID: "3201799999999", Name: "Sideloaded", AppType: 2})
+
+	assert.NoError(t, client.Open("3201799999999"))
+
+	app, _ := tv.App("3201799999999")
+	assert.True(t, app.Visible)
+}
+
+func TestCloseApp(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+	client.TokenStore = store.NewMemoryStore()
+	ctx := context.Background()
+
+	tv.AddApp(samsungtvtest.App{ID: "3201907018807", Name: "Netflix", AppType: 2, Running: true, Visible: true})
+
+	assert.NoError(t, client.ConnectionSetup())
+	defer client.Disconnect()
+
+	status, err := client.AppStatus(ctx, "netflix")
+	assert.NoError(t, err)
+	assert.True(t, status.Visible)
+
+	foreground, err := client.AppManager.ForegroundApp(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "3201907018807", foreground.ID)
+
+	result, err := client.CloseApp(ctx, "netflix")
+	assert.NoError(t, err)
+	assert.Equal(t, apps.Closed, result.Outcome)
+
+	app, _ := tv.App("3201907018807")
+	assert.False(t, app.Running)
+}
+
+func TestAppsCache(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+	client.TokenStore = store.NewMemoryStore()
+	ctx := context.Background()
+
+	tv.AddApp(samsungtvtest.App{ID: "3201907018807", Name: "Netflix", AppType: 2})
+
+	assert.NoError(t, client.ConnectionSetup())
+	defer client.Disconnect()
+
+	catalogue, err := client.Apps(ctx)
+	assert.NoError(t, err)
+	assert.True(t, catalogue.Apps[0].Installed)
+	assert.NotEmpty(t, client.cfg.Apps)
+
+	tv.AddApp(samsungtvtest.App{ID: "3201512006963", Name: "Plex", AppType: 2})
+
+	cached, err := client.Apps(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, len(catalogue.Apps), len(cached.Apps))
+
+	plex, err := cached.Find("plex")
+	assert.NoError(t, err)
+	assert.False(t, plex.Installed)
+
+	refreshed, err := client.RefreshApps(ctx)
+	assert.NoError(t, err)
+
+	plex, err = refreshed.Find("plex")
+	assert.NoError(t, err)
+	assert.True(t, plex.Installed)
+}
+
+func TestOpenDeepLink(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+	client.TokenStore = store.NewMemoryStore()
+	ctx := context.Background()
+
+	tv.AddApp(samsungtvtest.App{ID: apps.YouTubeID, Name: "YouTube", AppType: apps.TypeWebApp})
+
+	assert.NoError(t, client.ConnectionSetup())
+	defer client.Disconnect()
+
+	video, _ := apps.YouTube("dQw4w9WgXcQ")
+	assert.NoError(t, client.OpenDeepLink(ctx, video))
+
+	page, _ := apps.Browser("https://example.com")
+	assert.NoError(t, client.OpenDeepLink(ctx, page))
+
+	title, _ := apps.Netflix("80100172")
+	assert.ErrorIs(t, client.OpenDeepLink(ctx, title), tverrors.ErrAppNotFound)
+
+	assert.Eventually(t, func() bool {
+		return len(tv.Launches()) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, []samsungtvtest.Launch{
+		{AppID: apps.YouTubeID, ActionType: "DEEP_LINK", MetaTag: "v=dQw4w9WgXcQ"},
+		{AppID: apps.BrowserID, ActionType: "NATIVE_LAUNCH", MetaTag: "https://example.com"},
+	}, tv.Launches())
+}
+
+func TestOpenDeepLinkNativeApp(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+	client.TokenStore = store.NewMemoryStore()
+	ctx := context.Background()
+
+	// only web applications are deep linked, any other app_type is launched
+	// natively with the metaTag.
+	tv.AddApp(samsungtvtest.App{ID: "111299001912", Name: "Gallery", AppType: 1})
+
+	assert.NoError(t, client.ConnectionSetup())
+	defer client.Disconnect()
+
+	assert.NoError(t, client.OpenDeepLink(ctx, apps.DeepLink{AppID: "111299001912", MetaTag: "album=1"}))
+
+	assert.Eventually(t, func() bool {
+		return len(tv.Launches()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, []samsungtvtest.Launch{
+		{AppID: "111299001912", ActionType: "NATIVE_LAUNCH", MetaTag: "album=1"},
+	}, tv.Launches())
+}
+
+func TestWatchApps(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+	client.TokenStore = store.NewMemoryStore()
+
+	tv.AddApp(samsungtvtest.App{ID: "3201907018807", Name: "Netflix", AppType: 2})
+
+	assert.NoError(t, client.ConnectionSetup())
+	defer client.Disconnect()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the interval is never reached, the change is noticed through the
+	// ed.apps.launch event.
+	changes := client.WatchApps(ctx, apps.WatchOptions{Interval: time.Hour, Apps: []string{"3201907018807"}})
+
+	time.Sleep(50 * time.Millisecond)
+	_, err := client.LaunchApp(ctx, "netflix")
+	assert.NoError(t, err)
+
+	select {
+	case change := <-changes:
+		assert.Equal(t, "", change.From.ID)
+		assert.Equal(t, "3201907018807", change.To.ID)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the application to change")
+	}
+}
@@ -0,0 +1,561 @@
+package art
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/websocket"
+)
+
+// This package covers the art mode api of Frame TVs, which is served by the
+// art app over its own websocket channel.
+
+// Channel is the websocket channel the art app listens on.
+const Channel = "com.samsung.art-app"
+
+// MyPhotosCategory is the category of artwork uploaded by the user.
+const MyPhotosCategory = "MY-C0002"
+
+// responseEvent is the event every response from the art app is wrapped in.
+const responseEvent = "d2d_service_message"
+
+type Client struct {
+	Websocket   *websocket.SamsungWebsocket
+	connectLock sync.Mutex
+}
+
+// NewClient returns a client for the art app which connects using the provided
+// base url once the first request is made.
+func NewClient(baseUrl func(string) *url.URL) *Client {
+	return &Client{
+		Websocket: &websocket.SamsungWebsocket{
+			BaseUrl:     baseUrl,
+			Channel:     Channel,
+			IDExtractor: RequestID,
+		},
+	}
+}
+
+// RequestID returns the request id of a response from the art app, which is
+// sent as a JSON encoded string within the data of the event.
+func RequestID(ev websocket.Event) string {
+	msg, err := decodeMessage(ev)
+
+	if err != nil {
+		return ""
+	}
+
+	if msg.RequestID != "" {
+		return msg.RequestID
+	}
+
+	return msg.ID
+}
+
+// decodeMessage decodes the JSON encoded string held within the data of a
+// response from the art app.
+func decodeMessage(ev websocket.Event) (message, error) {
+	var msg message
+	var data string
+
+	if err := json.Unmarshal(ev.Data, &data); err != nil {
+		return msg, err
+	}
+
+	return msg, json.Unmarshal([]byte(data), &msg)
+}
+
+// newRequestID returns a random version 4 UUID used to correlate a request
+// with its response.
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// connect opens the art channel when not already connected, waiting for the
+// art app to report it is ready.
+func (c *Client) connect(ctx context.Context) error {
+	c.connectLock.Lock()
+	defer c.connectLock.Unlock()
+
+	if c.Websocket.State() == websocket.StateConnected {
+		return nil
+	}
+
+	if _, err := c.Websocket.OpenConnectionContext(ctx); err != nil {
+		return err
+	}
+
+	return c.Websocket.WaitForContext(ctx, "ms.channel.ready")
+}
+
+// newRequest wraps the art request into the event sent over the channel, the
+// art app expects the request as a JSON encoded string.
+func newRequest(name, id string, params map[string]interface{}) (websocket.Request, error) {
+	data := map[string]interface{}{
+		"request":    name,
+		"id":         id,
+		"request_id": id,
+	}
+
+	for key, value := range params {
+		data[key] = value
+	}
+
+	payload, err := json.Marshal(data)
+
+	if err != nil {
+		return websocket.Request{}, err
+	}
+
+	return websocket.Request{
+		Method: "ms.channel.emit",
+		Params: map[string]interface{}{
+			"event": "art_app_request",
+			"to":    "host",
+			"data":  string(payload),
+		},
+	}, nil
+}
+
+// request will send the request to the art app and unmarshal the response into
+// the output interface, returning an error if the art app reported one.
+func (c *Client) request(ctx context.Context, name string, params map[string]interface{}, output interface{}) error {
+	if err := c.connect(ctx); err != nil {
+		return err
+	}
+
+	id := newRequestID()
+	req, err := newRequest(name, id, params)
+
+	if err != nil {
+		return err
+	}
+
+	ev, err := c.Websocket.Call(ctx, req, websocket.Expect{Event: responseEvent, ID: id})
+
+	if err != nil {
+		return err
+	}
+
+	return decodeResponse(name, ev, output)
+}
+
+// decodeResponse decodes a response from the art app into the output
+// interface, returning an error if the art app reported one.
+func decodeResponse(name string, ev websocket.Event, output interface{}) error {
+	msg, err := decodeMessage(ev)
+
+	if err != nil {
+		return err
+	}
+
+	if msg.Event == "error" {
+		return artError(name, msg)
+	}
+
+	if output == nil {
+		return nil
+	}
+
+	var data string
+	if err := json.Unmarshal(ev.Data, &data); err != nil {
+		return err
+	}
+
+	return json.Unmarshal([]byte(data), output)
+}
+
+// artError converts an error reported by the art app into a *tverrors.TVError.
+func artError(name string, msg message) error {
+	return &tverrors.TVError{
+		Event:   "art_app_request",
+		Message: fmt.Sprintf("%s failed with error code %s", name, msg.ErrorCode),
+	}
+}
+
+// GetArtMode returns true if and only if the TV is currently showing art.
+func (c *Client) GetArtMode(ctx context.Context) (bool, error) {
+	log.Println("Get art mode status via art api")
+
+	var output message
+	err := c.request(ctx, "get_artmode_status", nil, &output)
+
+	return output.Value == "on", err
+}
+
+// SetArtMode will turn art mode on or off.
+func (c *Client) SetArtMode(ctx context.Context, on bool) error {
+	log.Printf("Set art mode to %t via art api\n", on)
+
+	value := "off"
+	if on {
+		value = "on"
+	}
+
+	return c.request(ctx, "set_artmode_status", map[string]interface{}{"value": value}, nil)
+}
+
+// ListArtwork returns the artwork stored on the TV within the given category,
+// or all artwork when the category is empty.
+func (c *Client) ListArtwork(ctx context.Context, category string) ([]Artwork, error) {
+	log.Println("Get artwork list via art api")
+
+	params := map[string]interface{}{}
+	if category != "" {
+		params["category"] = category
+	}
+
+	var output contentListResponse
+	if err := c.request(ctx, "get_content_list", params, &output); err != nil {
+		return nil, err
+	}
+
+	var artwork []Artwork
+	if err := json.Unmarshal([]byte(output.ContentList), &artwork); err != nil {
+		return nil, err
+	}
+
+	if category == "" {
+		return artwork, nil
+	}
+
+	filtered := artwork[:0]
+	for _, item := range artwork {
+		if item.CategoryID == category {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered, nil
+}
+
+// SelectImage will select the given artwork, showing it straight away when
+// the TV is in art mode and show is true.
+func (c *Client) SelectImage(ctx context.Context, contentID string, show bool) error {
+	log.Printf("Select artwork %s via art api\n", contentID)
+
+	return c.request(ctx, "select_image", map[string]interface{}{
+		"content_id":  contentID,
+		"category_id": nil,
+		"show":        show,
+	}, nil)
+}
+
+// Upload will upload the JPEG or PNG image at the given path to the TV with
+// the provided matte, "none" is used when empty. The content id of the new
+// artwork is returned.
+func (c *Client) Upload(ctx context.Context, path, matte string) (string, error) {
+	log.Printf("Upload artwork %s via art api\n", path)
+
+	fileType, err := imageFileType(path)
+
+	if err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return "", err
+	}
+
+	if matte == "" {
+		matte = "none"
+	}
+
+	connectionID, err := rand.Int(rand.Reader, big.NewInt(4*1024*1024*1024))
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.connect(ctx); err != nil {
+		return "", err
+	}
+
+	// the image_added message is sent with a new request id, so listen for it
+	// before the upload starts.
+	added := c.Websocket.Subscribe(responseEvent)
+	defer c.Websocket.Unsubscribe(added)
+
+	id := newRequestID()
+	req, err := newRequest("send_image", id, map[string]interface{}{
+		"file_type": fileType,
+		"conn_info": map[string]interface{}{
+			"d2d_mode":      "socket",
+			"connection_id": connectionID.Int64(),
+			"id":            id,
+		},
+		"image_date":        time.Now().Format("2006:01:02 15:04:05"),
+		"matte_id":          matte,
+		"portrait_matte_id": matte,
+		"file_size":         len(content),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	ev, err := c.Websocket.Call(ctx, req, websocket.Expect{Event: responseEvent, ID: id})
+
+	if err != nil {
+		return "", err
+	}
+
+	var ready readyToUseResponse
+	if err := decodeResponse("send_image", ev, &ready); err != nil {
+		return "", err
+	}
+
+	var info connInfo
+	if err := json.Unmarshal([]byte(ready.ConnInfo), &info); err != nil {
+		return "", err
+	}
+
+	if err := uploadImage(ctx, c.Websocket.TLSConfig, info, fileType, content); err != nil {
+		return "", err
+	}
+
+	for {
+		select {
+		case ev := <-added:
+			msg, err := decodeMessage(ev)
+
+			if err != nil {
+				continue
+			}
+
+			if msg.Event == "error" && RequestID(ev) == id {
+				return "", artError("send_image", msg)
+			}
+
+			if msg.Event != "image_added" {
+				continue
+			}
+
+			var output imageAddedResponse
+			if err := decodeResponse("send_image", ev, &output); err != nil {
+				return "", err
+			}
+
+			return output.ContentID, nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+}
+
+// imageFileType returns the file type the art app expects for the image.
+func imageFileType(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg":
+		return "jpg", nil
+	case ".png":
+		return "png", nil
+	}
+
+	return "", errors.New("artwork must be a JPEG or PNG image")
+}
+
+// uploadImage sends the image over the socket opened by the art app, prefixed
+// with the length of a JSON header describing the image. A secured socket is
+// verified with the TLS configuration of the channel, so a pinned certificate
+// applies to uploads as well.
+func uploadImage(ctx context.Context, config *tls.Config, info connInfo, fileType string, content []byte) error {
+	header, err := json.Marshal(map[string]interface{}{
+		"num":        0,
+		"total":      1,
+		"fileLength": len(content),
+		"fileName":   "dummy",
+		"fileType":   fileType,
+		"secKey":     info.Key,
+		"version":    "0.0.1",
+	})
+
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(info.IP, info.Port.String()))
+
+	if err != nil {
+		return err
+	}
+
+	defer func(conn net.Conn) {
+		_ = conn.Close()
+	}(conn)
+
+	if info.Secured {
+		if config == nil {
+			config = &tls.Config{InsecureSkipVerify: true}
+		}
+
+		config = config.Clone()
+
+		if config.ServerName == "" {
+			config.ServerName = info.IP
+		}
+
+		tlsConn := tls.Client(conn, config)
+
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return err
+		}
+
+		conn = tlsConn
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	size := make([]byte, 4)
+	binary.BigEndian.PutUint32(size, uint32(len(header)))
+
+	for _, part := range [][]byte{size, header, content} {
+		if _, err := conn.Write(part); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete will delete the given artwork from the TV.
+func (c *Client) Delete(ctx context.Context, contentIDs ...string) error {
+	log.Printf("Delete artwork %s via art api\n", strings.Join(contentIDs, ", "))
+
+	var list []map[string]string
+	for _, id := range contentIDs {
+		list = append(list, map[string]string{"content_id": id})
+	}
+
+	return c.request(ctx, "delete_image_list", map[string]interface{}{"content_id_list": list}, nil)
+}
+
+// GetMatteList returns the matte types and colours supported by the TV.
+func (c *Client) GetMatteList(ctx context.Context) (MatteList, error) {
+	log.Println("Get matte list via art api")
+
+	var output matteListResponse
+	if err := c.request(ctx, "get_matte_list", nil, &output); err != nil {
+		return MatteList{}, err
+	}
+
+	var types []matteType
+	if err := json.Unmarshal([]byte(output.MatteTypeList), &types); err != nil {
+		return MatteList{}, err
+	}
+
+	var colors []matteColor
+	if output.MatteColorList != "" {
+		if err := json.Unmarshal([]byte(output.MatteColorList), &colors); err != nil {
+			return MatteList{}, err
+		}
+	}
+
+	var list MatteList
+	for _, t := range types {
+		list.Types = append(list.Types, t.MatteType)
+	}
+	for _, color := range colors {
+		list.Colors = append(list.Colors, color.Color)
+	}
+
+	return list, nil
+}
+
+// ChangeMatte will change the matte of the given artwork.
+func (c *Client) ChangeMatte(ctx context.Context, contentID, matteID string) error {
+	log.Printf("Change matte of artwork %s to %s via art api\n", contentID, matteID)
+
+	return c.request(ctx, "change_matte", map[string]interface{}{
+		"content_id": contentID,
+		"matte_id":   matteID,
+	}, nil)
+}
+
+// GetPhotoFilterList returns the photo filters supported by the TV.
+func (c *Client) GetPhotoFilterList(ctx context.Context) ([]string, error) {
+	log.Println("Get photo filter list via art api")
+
+	var output photoFilterListResponse
+	if err := c.request(ctx, "get_photo_filter_list", nil, &output); err != nil {
+		return nil, err
+	}
+
+	var filters []photoFilter
+	if err := json.Unmarshal([]byte(output.FilterList), &filters); err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, filter := range filters {
+		ids = append(ids, filter.FilterID)
+	}
+
+	return ids, nil
+}
+
+// SetPhotoFilter will apply the photo filter to the given artwork.
+func (c *Client) SetPhotoFilter(ctx context.Context, contentID, filterID string) error {
+	log.Printf("Set photo filter of artwork %s to %s via art api\n", contentID, filterID)
+
+	return c.request(ctx, "set_photo_filter", map[string]interface{}{
+		"content_id": contentID,
+		"filter_id":  filterID,
+	}, nil)
+}
+
+// GetBrightness returns the brightness used while showing art.
+func (c *Client) GetBrightness(ctx context.Context) (int, error) {
+	log.Println("Get art brightness via art api")
+
+	var output message
+	if err := c.request(ctx, "get_brightness", nil, &output); err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(output.Value)
+}
+
+// GetColorTemperature returns the colour temperature used while showing art.
+func (c *Client) GetColorTemperature(ctx context.Context) (int, error) {
+	log.Println("Get art colour temperature via art api")
+
+	var output message
+	if err := c.request(ctx, "get_color_temperature", nil, &output); err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(output.Value)
+}
+
+// Disconnect closes the art channel.
+func (c *Client) Disconnect() error {
+	return c.Websocket.Disconnect()
+}
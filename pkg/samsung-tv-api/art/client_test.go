@@ -0,0 +1,174 @@
+package art
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/samsungtvtest"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
+)
+
+// newTestClient starts an art app which answers every request with the value
+// returned by respond for the request name.
+func newTestClient(t *testing.T, respond func(request map[string]interface{}) map[string]interface{}) *Client {
+	srv := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		_ = websocket.Message.Send(ws, `{"event":"ms.channel.connect","data":{}}`)
+		_ = websocket.Message.Send(ws, `{"event":"ms.channel.ready","data":{}}`)
+
+		for {
+			var frame struct {
+				Params struct {
+					Data string `json:"data"`
+				} `json:"params"`
+			}
+			if err := websocket.JSON.Receive(ws, &frame); err != nil {
+				return
+			}
+
+			var request map[string]interface{}
+			_ = json.Unmarshal([]byte(frame.Params.Data), &request)
+
+			response := respond(request)
+			response["request_id"] = request["request_id"]
+			data, _ := json.Marshal(response)
+
+			_ = websocket.JSON.Send(ws, map[string]interface{}{
+				"event": responseEvent,
+				"data":  string(data),
+			})
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return NewClient(func(endpoint string) *url.URL {
+		return &url.URL{Scheme: "ws", Host: strings.TrimPrefix(srv.URL, "http://"), Path: endpoint}
+	})
+}
+
+func TestGetArtMode(t *testing.T) {
+	client := newTestClient(t, func(request map[string]interface{}) map[string]interface{} {
+		assert.Equal(t, "get_artmode_status", request["request"])
+		return map[string]interface{}{"event": "artmode_status", "value": "on"}
+	})
+	defer client.Disconnect()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	on, err := client.GetArtMode(ctx)
+	assert.NoError(t, err)
+	assert.True(t, on)
+}
+
+func TestListArtworkFiltersCategory(t *testing.T) {
+	client := newTestClient(t, func(request map[string]interface{}) map[string]interface{} {
+		list, _ := json.Marshal([]Artwork{
+			{ContentID: "MY_F0001", CategoryID: MyPhotosCategory},
+			{ContentID: "SAM-S0700", CategoryID: "MY-C0004"},
+		})
+		return map[string]interface{}{"event": "get_content_list", "content_list": string(list)}
+	})
+	defer client.Disconnect()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	artwork, err := client.ListArtwork(ctx, MyPhotosCategory)
+	assert.NoError(t, err)
+	assert.Len(t, artwork, 1)
+	assert.Equal(t, "MY_F0001", artwork[0].ContentID)
+}
+
+func TestRequestError(t *testing.T) {
+	client := newTestClient(t, func(request map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"event": "error", "error_code": "-1"}
+	})
+	defer client.Disconnect()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := client.GetBrightness(ctx)
+	var tvErr *tverrors.TVError
+	assert.ErrorAs(t, err, &tvErr)
+	assert.Equal(t, "get_brightness failed with error code -1", tvErr.Message)
+}
+
+// newFakeTVClient returns a client connecting to the art app of a fake TV.
+func newFakeTVClient(t *testing.T) (*Client, *samsungtvtest.Server) {
+	tv := samsungtvtest.NewServer()
+	t.Cleanup(tv.Close)
+
+	client := NewClient(tv.WebsocketBaseUrl)
+	t.Cleanup(func() { _ = client.Disconnect() })
+
+	return client, tv
+}
+
+func TestUpload(t *testing.T) {
+	client, tv := newFakeTVClient(t)
+
+	image := []byte("\x89PNG\r\n\x1a\nnot really an image")
+	path := filepath.Join(t.TempDir(), "art.png")
+	assert.NoError(t, os.WriteFile(path, image, 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	contentID, err := client.Upload(ctx, path, "shadowbox_polar")
+	assert.NoError(t, err)
+	assert.Equal(t, "MY_F0001", contentID)
+
+	artwork := tv.Artwork()
+	assert.Len(t, artwork, 1)
+	assert.Equal(t, contentID, artwork[0].ContentID)
+	assert.Equal(t, "shadowbox_polar", artwork[0].MatteID)
+	assert.Equal(t, "png", artwork[0].FileType)
+	assert.Equal(t, image, artwork[0].Data)
+
+	listed, err := client.ListArtwork(ctx, MyPhotosCategory)
+	assert.NoError(t, err)
+	assert.Len(t, listed, 1)
+	assert.Equal(t, contentID, listed[0].ContentID)
+}
+
+func TestSettersWaitForTheArtApp(t *testing.T) {
+	client, tv := newFakeTVClient(t)
+
+	path := filepath.Join(t.TempDir(), "art.jpg")
+	assert.NoError(t, os.WriteFile(path, []byte("jpeg"), 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	contentID, err := client.Upload(ctx, path, "")
+	assert.NoError(t, err)
+
+	// every setter returns once the art app answered, so the state of the
+	// fake TV is checked straight away.
+	assert.NoError(t, client.SetArtMode(ctx, true))
+	assert.True(t, tv.ArtMode())
+
+	assert.NoError(t, client.SelectImage(ctx, contentID, true))
+	assert.Equal(t, contentID, tv.SelectedArtwork())
+
+	assert.NoError(t, client.ChangeMatte(ctx, contentID, "modern_apricot"))
+	assert.NoError(t, client.SetPhotoFilter(ctx, contentID, "ink"))
+
+	artwork := tv.Artwork()
+	assert.Equal(t, "modern_apricot", artwork[0].MatteID)
+	assert.Equal(t, "ink", artwork[0].FilterID)
+
+	var tvErr *tverrors.TVError
+	assert.ErrorAs(t, client.SelectImage(ctx, "MY_F9999", true), &tvErr)
+	assert.Equal(t, "select_image failed with error code -1", tvErr.Message)
+}
@@ -0,0 +1,74 @@
+package art
+
+import "encoding/json"
+
+// message is the decoded data of every d2d_service_message sent by the art
+// app, the remaining fields depend on the request.
+type message struct {
+	Event     string `json:"event"`
+	ID        string `json:"id"`
+	RequestID string `json:"request_id"`
+	ErrorCode string `json:"error_code"`
+	Value     string `json:"value"`
+}
+
+// Artwork is a single piece of artwork stored on the TV.
+type Artwork struct {
+	ContentID       string `json:"content_id"`
+	CategoryID      string `json:"category_id"`
+	FileName        string `json:"file_name"`
+	FileType        string `json:"file_type"`
+	Width           int    `json:"width"`
+	Height          int    `json:"height"`
+	ImageDate       string `json:"image_date"`
+	MatteID         string `json:"matte_id"`
+	PortraitMatteID string `json:"portrait_matte_id"`
+	ContentType     string `json:"content_type"`
+}
+
+// MatteList holds the matte types and colours which can be combined into a
+// matte id in the form <type>_<colour>, e.g. "shadowbox_polar".
+type MatteList struct {
+	Types  []string
+	Colors []string
+}
+
+type contentListResponse struct {
+	ContentList string `json:"content_list"`
+}
+
+type matteListResponse struct {
+	MatteTypeList  string `json:"matte_type_list"`
+	MatteColorList string `json:"matte_color_list"`
+}
+
+type matteType struct {
+	MatteType string `json:"matte_type"`
+}
+
+type matteColor struct {
+	Color string `json:"color"`
+}
+
+type photoFilterListResponse struct {
+	FilterList string `json:"filter_list"`
+}
+
+type photoFilter struct {
+	FilterID string `json:"filter_id"`
+}
+
+type readyToUseResponse struct {
+	ConnInfo string `json:"conn_info"`
+}
+
+type connInfo struct {
+	IP      string      `json:"ip"`
+	Port    json.Number `json:"port"`
+	Key     string      `json:"key"`
+	Secured bool        `json:"secured"`
+}
+
+type imageAddedResponse struct {
+	ContentID string `json:"content_id"`
+}
@@ -1,60 +1,162 @@
 package samsung_tv_api
 
 import (
+	"context"
+	"crypto/tls"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
+	"net"
+	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/stephensli/samsung-tv-api/internal/app/samsung-tv-api/wol"
 	"github.com/stephensli/samsung-tv-api/pkg/device"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/apps"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/art"
 	samsung_http "github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/http"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/keys"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/store"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
 	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/websocket"
 	"github.com/stephensli/samsung-tv-api/pkg/upnp"
 )
 
+const (
+	// DefaultPort serves the rest api and websocket channels over TLS.
+	DefaultPort = 8002
+	// LegacyPort serves the rest api and websocket channels over plain http
+	// and ws, being the only port of 2016-2017 TVs.
+	LegacyPort = 8001
+	// DefaultUpnpPort serves the upnp services.
+	DefaultUpnpPort = 9197
+)
+
 type SamsungTvClient struct {
-	Rest          samsung_http.SamsungRestClient
-	Websocket     websocket.SamsungWebsocket
-	Upnp          upnp.UpnpClient
-	port          int
-	cfg           *device.DeviceInfo
-	keyPressDelay int
-	name          string
+	Rest      samsung_http.SamsungRestClient
+	Websocket websocket.SamsungWebsocket
+	Upnp      upnp.UpnpClient
+	Art       *art.Client
+	// TokenStore persists the token issued by the TV when set, see Pair.
+	TokenStore store.TokenStore
+	// OnPairingState is called with every state reached while pairing.
+	OnPairingState func(PairingState)
+	// PowerPolicy configures the retries of SetPower.
+	PowerPolicy PowerPolicy
+	// WakeOptions configures the magic packet sent by Wake.
+	WakeOptions WakeOptions
+	// AppsTTL is how long the catalogue of applications is cached for,
+	// DefaultAppsTTL when zero.
+	AppsTTL time.Duration
+	// AppManager launches and closes applications through the rest api,
+	// confirming they started or closed.
+	AppManager apps.Manager
+	// port is set through WithPort, the port of the device is probed for
+	// when zero, see Probe.
+	port       int
+	upnpPort   int
+	tlsConfig  *tls.Config
+	httpClient *http.Client
+	// seenFingerprint is the fingerprint of the certificate presented on the
+	// last TLS connection, guarded by pinMutex.
+	seenFingerprint string
+	pinMutex        sync.Mutex
+	cfg             *device.DeviceInfo
+	keyPressDelay   int
+	unknownKeys     bool
+	name            string
 }
 
-func NewSamsungTvWebSocket(cfg *device.DeviceInfo, keyPressDelay int, autoConnect bool) *SamsungTvClient {
-	if keyPressDelay == 0 {
-		keyPressDelay = 1
-	}
+// New returns a client for the provided TV configured by the options. Unless
+// WithPort is provided, the port of the TV is probed for when first needed,
+// falling back from DefaultPort to LegacyPort, and remembered in the Port of
+// the device.
+//
+// Unless WithTLSConfig is provided, the certificate of the TV is pinned to the
+// CertFingerprint of the device, which is recorded on the first connection.
+// Connecting to a TV presenting another certificate fails with an error
+// matching tverrors.ErrCertificateMismatch, see Trust.
+func New(cfg *device.DeviceInfo, opts ...Option) *SamsungTvClient {
 	client := &SamsungTvClient{
 		name:          "RoomsAI Remote",
 		cfg:           cfg,
-		port:          8002,
-		keyPressDelay: keyPressDelay,
+		upnpPort:      DefaultUpnpPort,
+		keyPressDelay: 1,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	restClient := client.httpClient
+
+	if client.tlsConfig == nil {
+		client.tlsConfig = client.pinnedTLSConfig()
+
+		if restClient != nil {
+			restClient = client.pinnedHTTPClient(restClient)
+		}
+	}
+
+	// the client is built once so connections to the TV are kept alive
+	// between requests.
+	if restClient == nil {
+		restClient = &http.Client{
+			Transport: &http.Transport{TLSClientConfig: client.tlsConfig},
+		}
 	}
 
 	client.Rest = samsung_http.SamsungRestClient{
 		BaseUrl: func(endpoint string) *url.URL {
 			return client.formatRestUrl(endpoint)
 		},
+		HTTPClient: restClient,
+		TLSConfig:  client.tlsConfig,
+	}
+
+	client.AppManager = apps.Manager{
+		Rest:       &client.Rest,
+		Candidates: client.appCandidates,
 	}
 
 	client.Websocket = websocket.SamsungWebsocket{
 		BaseUrl: func(endpoint string) *url.URL {
 			return client.formatWebSocketUrl(endpoint)
 		},
-		KeyPressDelay: keyPressDelay,
+		KeyPressDelay:    client.keyPressDelay,
+		AllowUnknownKeys: client.unknownKeys,
+		OnConnect:        client.updateToken,
+		TLSConfig:        client.tlsConfig,
 	}
 
+	client.Art = art.NewClient(func(endpoint string) *url.URL {
+		return client.formatWebSocketUrl(endpoint)
+	})
+	client.Art.Websocket.TLSConfig = client.tlsConfig
+
 	client.Upnp = upnp.UpnpClient{
 		BaseUrl: func(endpoint string) *url.URL {
 			return client.formatUpnpUrl(endpoint)
 		},
+		HTTPClient: client.httpClient,
 	}
 
+	return client
+}
+
+// NewSamsungTvWebSocket returns a client for the provided TV, see New.
+func NewSamsungTvWebSocket(cfg *device.DeviceInfo, keyPressDelay int, autoConnect bool) *SamsungTvClient {
+	if keyPressDelay == 0 {
+		keyPressDelay = 1
+	}
+
+	client := New(cfg, WithKeyPressDelay(keyPressDelay))
+
 	if autoConnect {
 		if err := client.ConnectionSetup(); err != nil {
 			log.Fatalln(err)
@@ -68,23 +170,114 @@ func NewSamsungTvWebSocket(cfg *device.DeviceInfo, keyPressDelay int, autoConnec
 // the TV while after connecting, update the internal token to the newest value
 // regardless if its the same.
 func (s *SamsungTvClient) ConnectionSetup() error {
-	wsResp, err := s.Websocket.OpenConnection()
+	return s.ConnectionSetupContext(context.Background())
+}
 
-	if err != nil {
-		return err
-	}
+// ConnectionSetupContext is ConnectionSetup bound to the provided context.
+func (s *SamsungTvClient) ConnectionSetupContext(ctx context.Context) error {
+	s.resolvePort(ctx)
 
-	if len(wsResp.Data.Clients) > 0 && wsResp.Data.Token != "" {
+	_, err := s.Websocket.OpenConnectionContext(ctx)
+	return err
+}
+
+// EnableReconnect opts in to the websocket connection being re-established
+// automatically when dropped, see websocket.SamsungWebsocket.EnableReconnect.
+// Any token issued when reconnecting replaces the current token.
+func (s *SamsungTvClient) EnableReconnect(policy websocket.ReconnectPolicy) {
+	s.Websocket.EnableReconnect(policy)
+}
+
+// updateToken is called for every websocket connection opened, pinning the
+// certificate of the TV when none is trusted yet and updating the internal
+// token when the TV issues one and saving it to the TokenStore.
+func (s *SamsungTvClient) updateToken(wsResp *websocket.ConnectionResponse) {
+	s.pinCertificate()
+
+	if len(wsResp.Data.Clients) > 0 && wsResp.Data.Token != "" && wsResp.Data.Token != s.cfg.Token {
 		s.cfg.Token = wsResp.Data.Token
+
+		if err := s.saveToken(); err != nil {
+			log.Printf("unable to save token: %v", err)
+		}
 	}
+}
 
-	return nil
+// Port returns the port of the rest api and websocket channels: the port set
+// through WithPort, otherwise the port remembered for the device when probed,
+// otherwise DefaultPort.
+func (s *SamsungTvClient) Port() int {
+	if s.port != 0 {
+		return s.port
+	}
+
+	if s.cfg.Port != 0 {
+		return s.cfg.Port
+	}
+
+	return DefaultPort
+}
+
+// Probe finds the port the TV serves the rest api on, trying DefaultPort over
+// TLS before LegacyPort over plain http. The port which answered is remembered
+// in the Port of the device.
+//
+// A device with a trusted certificate is never downgraded to LegacyPort.
+func (s *SamsungTvClient) Probe(ctx context.Context) (int, error) {
+	var errs []error
+
+	ports := []int{DefaultPort, LegacyPort}
+
+	if s.cfg.CertFingerprint != "" {
+		ports = ports[:1]
+	}
+
+	for _, port := range ports {
+		rest := s.Rest
+		rest.BaseUrl = func(endpoint string) *url.URL {
+			return s.formatRestUrlForPort(port, endpoint)
+		}
+
+		if _, err := rest.GetDeviceInfoContext(ctx); err != nil {
+			if errors.Is(err, tverrors.ErrCertificateMismatch) {
+				return 0, err
+			}
+
+			errs = append(errs, fmt.Errorf("port %d: %w", port, err))
+			continue
+		}
+
+		log.Printf("tv is listening on port %d\n", port)
+		s.cfg.Port = port
+
+		return port, nil
+	}
+
+	return 0, errors.Join(errs...)
+}
+
+// probed returns true if the port is known, either set through WithPort or
+// remembered for the device.
+func (s *SamsungTvClient) probed() bool {
+	return s.port != 0 || s.cfg.Port != 0
+}
+
+// resolvePort probes for the port of the TV when not yet known, DefaultPort is
+// used when the TV does not answer on any port.
+func (s *SamsungTvClient) resolvePort(ctx context.Context) {
+	if s.probed() {
+		return
+	}
+
+	if _, err := s.Probe(ctx); err != nil {
+		log.Printf("unable to probe tv port: %v\n", err)
+	}
 }
 
-// isSslConnection returns true if and only if the port is the SSL port for the
-// connection otherwise it is not configured for SSL.
+// isSslConnection returns true if and only if the port is served over TLS,
+// being every port other than LegacyPort.
 func (s *SamsungTvClient) isSslConnection() bool {
-	return s.port == 8002
+	return s.Port() != LegacyPort
 }
 
 // formatWebSocketUrl returns the formatted web socket url for connecting
@@ -97,7 +290,7 @@ func (s *SamsungTvClient) formatWebSocketUrl(endpoint string) *url.URL {
 
 	u := &url.URL{
 		Scheme:   "ws",
-		Host:     fmt.Sprintf("%s:%d", s.cfg.Ip, s.port),
+		Host:     net.JoinHostPort(s.cfg.Ip, strconv.Itoa(s.Port())),
 		Path:     fmt.Sprintf("api/v2/channels%s", endpoint),
 		RawQuery: fmt.Sprintf("name=%s", name),
 	}
@@ -113,6 +306,11 @@ func (s *SamsungTvClient) formatWebSocketUrl(endpoint string) *url.URL {
 // formatRestUrl returns the formatted rest api url for connecting to
 // the tv rest service
 func (s *SamsungTvClient) formatRestUrl(endpoint string) *url.URL {
+	return s.formatRestUrlForPort(s.Port(), endpoint)
+}
+
+// formatRestUrlForPort returns the formatted rest api url on the given port.
+func (s *SamsungTvClient) formatRestUrlForPort(port int, endpoint string) *url.URL {
 	if endpoint != "" && string(endpoint[0]) != "/" {
 		endpoint = "/" + endpoint
 	}
@@ -123,11 +321,11 @@ func (s *SamsungTvClient) formatRestUrl(endpoint string) *url.URL {
 
 	u := &url.URL{
 		Scheme: "http",
-		Host:   fmt.Sprintf("%s:%d", s.cfg.Ip, s.port),
+		Host:   net.JoinHostPort(s.cfg.Ip, strconv.Itoa(port)),
 		Path:   fmt.Sprintf("api/v2%s", endpoint),
 	}
 
-	if s.isSslConnection() {
+	if port != LegacyPort {
 		u.Scheme += "s"
 	}
 
@@ -143,7 +341,7 @@ func (s *SamsungTvClient) formatUpnpUrl(endpoint string) *url.URL {
 
 	u := &url.URL{
 		Scheme: "http",
-		Host:   fmt.Sprintf("%s:%d", s.cfg.Ip, 9197),
+		Host:   net.JoinHostPort(s.cfg.Ip, strconv.Itoa(s.upnpPort)),
 		Path:   fmt.Sprintf("upnp/control%s", endpoint),
 	}
 
@@ -151,9 +349,22 @@ func (s *SamsungTvClient) formatUpnpUrl(endpoint string) *url.URL {
 }
 
 func (s *SamsungTvClient) Disconnect() error {
+	_ = s.Art.Disconnect()
 	return s.Websocket.Disconnect()
 }
 
+// IsFrameTV returns true if and only if the TV reports support for the art
+// mode api, see the Art client.
+func (s *SamsungTvClient) IsFrameTV(ctx context.Context) (bool, error) {
+	deviceInfo, err := s.Rest.GetDeviceInfoContext(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	return deviceInfo.Device.FrameTVSupport == "true", nil
+}
+
 // GetToken returns the current Auth token used by the client.
 func (s *SamsungTvClient) GetToken() string {
 	return s.cfg.Token
@@ -171,106 +382,210 @@ func WakeOnLan(mac string) error {
 	return packet.Send("255.255.255.255")
 }
 
-func (s *SamsungTvClient) IsAlive() bool {
-	_, deviceInfoErr := s.Rest.GetDeviceInfo()
-	if deviceInfoErr != nil {
-		return false
-	}
-	return true
+// WakeOptions configures how the magic packet is sent, see wol.Options.
+type WakeOptions = wol.Options
+
+// WakeOnLanWith sends the magic packet waking the device with the given mac
+// address as configured by the options.
+func WakeOnLanWith(mac string, opts WakeOptions) error {
+	return wol.Wake(mac, opts)
 }
 
-func (s *SamsungTvClient) PowerOn() error {
-	if s.IsAlive() {
-		s.ConnectionSetup()
-		// turned off TV reports volume -1
-		vol, _ := s.Upnp.GetCurrentVolume()
-		if vol == -1 {
-			s.Websocket.SendClick("KEY_POWER")
-		}
+// Wake sends the magic packet turning on the TV as configured by WakeOptions.
+// Unless a broadcast address or raw Ethernet is configured, the packet is sent
+// to the broadcast address of the subnet of the last known ip address of the
+// TV, which reaches it from multi-homed hosts and through routers forwarding
+// directed broadcasts.
+func (s *SamsungTvClient) Wake() error {
+	if s.cfg.Mac == "" {
+		return errors.New("unable to wake the TV without its mac address")
 	}
-	log.Printf("wol to %s", s.cfg.Mac)
-	WakeOnLan(s.cfg.Mac)
-	for s.IsAlive() == false {
-		time.Sleep(500 * time.Millisecond)
+
+	opts := s.WakeOptions
+
+	if opts.Broadcast == "" && !opts.Raw && s.cfg.Ip != "" {
+		if broadcast, err := wol.DirectedBroadcast(s.cfg.Ip); err == nil {
+			opts.Broadcast = broadcast
+		}
 	}
-	return nil
+
+	log.Printf("wol to %s via %s\n", s.cfg.Mac, opts.Broadcast)
+	return wol.Wake(s.cfg.Mac, opts)
 }
 
-func (s *SamsungTvClient) PowerOff() error {
-	s.Websocket.SendClick("KEY_POWER")
-	return nil
+// IsAlive returns true if the TV responds to the rest api.
+func (s *SamsungTvClient) IsAlive() bool {
+	return s.IsAliveContext(context.Background())
+}
+
+// IsAliveContext is IsAlive bound to the provided context.
+func (s *SamsungTvClient) IsAliveContext(ctx context.Context) bool {
+	return s.alive(ctx) == nil
+}
+
+// alive returns the error of requesting the device information, probing for
+// the port of the TV first when not yet known.
+func (s *SamsungTvClient) alive(ctx context.Context) error {
+	if !s.probed() {
+		_, err := s.Probe(ctx)
+		return err
+	}
+
+	_, err := s.Rest.GetDeviceInfoContext(ctx)
+	return err
 }
 
 func Discover() []device.DeviceInfo {
 	return upnp.Discover("urn:dial-multiscreen-org:service:dial:1", "Samsung Electronics", "samsungtv")
 }
 
-func (s *SamsungTvClient) Init() {
-	s.PowerOn()
-	s.ConnectionSetup()
-	s.Websocket.WaitFor("ms.channel.connect")
-	if s.cfg.Mac == "" {
-		deviceInfo, deviceInfoErr := s.Rest.GetDeviceInfo()
-		if deviceInfoErr == nil && deviceInfo.Device.NetworkType == "wireless" {
+func (s *SamsungTvClient) Init() error {
+	return s.InitContext(context.Background())
+}
+
+// InitContext is Init bound to the provided context, returning the first error
+// which stops the TV being ready to receive commands.
+func (s *SamsungTvClient) InitContext(ctx context.Context) error {
+	if err := s.LoadToken(); err != nil {
+		return err
+	}
+	if err := s.PowerOnContext(ctx); err != nil {
+		return err
+	}
+	// identify the device before connecting so any token issued is stored
+	// against the device id.
+	if s.cfg.Mac == "" || s.cfg.ID == "" {
+		deviceInfo, deviceInfoErr := s.Rest.GetDeviceInfoContext(ctx)
+		if deviceInfoErr == nil && deviceInfo.Device.NetworkType == "wireless" && s.cfg.Mac == "" {
 			s.cfg.Mac = deviceInfo.Device.WifiMac
 		}
+		if deviceInfoErr == nil && s.cfg.ID == "" && deviceInfo.Device.Duid != "" {
+			s.cfg.ID = deviceInfo.Device.Duid
+			// keep the token loaded by ip address under the device id.
+			if err := s.saveToken(); err != nil {
+				log.Printf("unable to save token: %v", err)
+			}
+		}
 	}
+	if s.cfg.Token == "" {
+		if err := s.LoadToken(); err != nil {
+			return err
+		}
+	}
+	if err := s.ConnectionSetupContext(ctx); err != nil {
+		return err
+	}
+	return s.Websocket.WaitForContext(ctx, "ms.channel.connect")
 }
 
 func (s *SamsungTvClient) List() error {
-	apps, _ := s.Websocket.GetApplicationsList()
+	return s.ListContext(context.Background())
+}
+
+// ListContext is List bound to the provided context.
+func (s *SamsungTvClient) ListContext(ctx context.Context) error {
+	apps, err := s.Websocket.GetApplicationsListContext(ctx)
+	if err != nil {
+		return err
+	}
 	for _, app := range apps.Data.Applications {
 		log.Printf("%s - %s", app.AppID, app.Name)
 	}
 	return nil
 }
 
+// Open opens urls in the browser and launches applications by name or id
+// otherwise, see LaunchApp.
 func (s *SamsungTvClient) Open(url string) error {
+	return s.OpenContext(context.Background(), url)
+}
+
+// OpenContext is Open bound to the provided context.
+func (s *SamsungTvClient) OpenContext(ctx context.Context, url string) error {
 	if strings.HasPrefix(url, "http") {
-		s.Websocket.OpenBrowser(url)
-	} else {
-		s.Rest.RunApplication(url)
+		return s.Websocket.OpenBrowserContext(ctx, url)
 	}
-	return nil
+
+	_, err := s.LaunchApp(ctx, url)
+	return err
 }
 
+// Key clicks the key, which is either a key code or a name matched with
+// keys.Parse such as "vol up". Keys matching nothing are only sent when the
+// websocket allows unknown keys.
 func (s *SamsungTvClient) Key(key string) error {
-	s.Websocket.SendClick(key)
-	return nil
+	return s.KeyContext(context.Background(), key)
+}
+
+// KeyContext is Key bound to the provided context.
+func (s *SamsungTvClient) KeyContext(ctx context.Context, key string) error {
+	if !keys.IsKey(key) {
+		k, err := keys.Parse(key)
+
+		if err == nil {
+			key = k.Code
+		} else if !s.Websocket.AllowUnknownKeys {
+			return err
+		}
+	}
+
+	return s.Websocket.SendClickContext(ctx, key)
 }
 
 func (s *SamsungTvClient) VolUp() error {
-	s.Websocket.SendClick("KEY_VOLUP")
-	return nil
+	return s.VolUpContext(context.Background())
+}
+
+// VolUpContext is VolUp bound to the provided context.
+func (s *SamsungTvClient) VolUpContext(ctx context.Context) error {
+	return s.Websocket.SendClickContext(ctx, "KEY_VOLUP")
 }
 
 func (s *SamsungTvClient) VolDown() error {
-	s.Websocket.SendClick("KEY_VOLDOWN")
-	return nil
+	return s.VolDownContext(context.Background())
 }
 
+// VolDownContext is VolDown bound to the provided context.
+func (s *SamsungTvClient) VolDownContext(ctx context.Context) error {
+	return s.Websocket.SendClickContext(ctx, "KEY_VOLDOWN")
+}
+
+// Vol sets the volume, returning the current volume when vol is -1.
 func (s *SamsungTvClient) Vol(vol int) (int, error) {
+	return s.VolContext(context.Background(), vol)
+}
+
+// VolContext is Vol bound to the provided context.
+func (s *SamsungTvClient) VolContext(ctx context.Context, vol int) (int, error) {
 	if vol == -1 {
-		vol, err := s.Upnp.GetCurrentVolume()
-		return vol, err
+		return s.Upnp.GetCurrentVolumeContext(ctx)
+	}
+	if err := s.Upnp.SetVolumeContext(ctx, vol); err != nil {
+		return -1, err
 	}
-	s.Upnp.SetVolume(vol)
 	return vol, nil
 }
 
 func (s *SamsungTvClient) Test() error {
-	s.Websocket.WaitFor("qwesdfsf")
-	return nil
+	return s.Websocket.WaitFor("qwesdfsf")
 }
 
 func (s *SamsungTvClient) Text(text string) error {
-	s.Websocket.SendText(base64.StdEncoding.EncodeToString([]byte(text)))
-	return nil
+	return s.TextContext(context.Background(), text)
+}
+
+// TextContext is Text bound to the provided context.
+func (s *SamsungTvClient) TextContext(ctx context.Context, text string) error {
+	return s.Websocket.SendTextContext(ctx, base64.StdEncoding.EncodeToString([]byte(text)))
 }
 
 func (s *SamsungTvClient) Stream(url string) error {
-	s.Upnp.SetCurrentMedia(url)
-	return nil
+	return s.StreamContext(context.Background(), url)
+}
+
+// StreamContext is Stream bound to the provided context.
+func (s *SamsungTvClient) StreamContext(ctx context.Context, url string) error {
+	return s.Upnp.SetCurrentMediaContext(ctx, url)
 }
 
 func (s *SamsungTvClient) Info() (string, error) {
@@ -278,29 +593,53 @@ func (s *SamsungTvClient) Info() (string, error) {
 }
 
 func (s *SamsungTvClient) Next() error {
-	s.Upnp.PlayNext()
-	return nil
+	return s.NextContext(context.Background())
+}
+
+// NextContext is Next bound to the provided context.
+func (s *SamsungTvClient) NextContext(ctx context.Context) error {
+	return s.Upnp.PlayNextContext(ctx)
 }
 
 func (s *SamsungTvClient) Prev() error {
-	s.Upnp.PlayPrevious()
-	return nil
+	return s.PrevContext(context.Background())
+}
+
+// PrevContext is Prev bound to the provided context.
+func (s *SamsungTvClient) PrevContext(ctx context.Context) error {
+	return s.Upnp.PlayPreviousContext(ctx)
 }
 
 func (s *SamsungTvClient) Pause() error {
-	s.Upnp.Pause()
-	return nil
+	return s.PauseContext(context.Background())
+}
+
+// PauseContext is Pause bound to the provided context.
+func (s *SamsungTvClient) PauseContext(ctx context.Context) error {
+	return s.Upnp.PauseContext(ctx)
 }
 
 func (s *SamsungTvClient) Play() error {
-	s.Upnp.PlayCurrentMedia()
-	return nil
+	return s.PlayContext(context.Background())
+}
+
+// PlayContext is Play bound to the provided context.
+func (s *SamsungTvClient) PlayContext(ctx context.Context) error {
+	return s.Upnp.PlayCurrentMediaContext(ctx)
 }
 
 func (s *SamsungTvClient) Status() (interface{}, error) {
-	out, err := s.Upnp.GetCurrentMedia()
+	return s.StatusContext(context.Background())
+}
+
+// StatusContext is Status bound to the provided context.
+func (s *SamsungTvClient) StatusContext(ctx context.Context) (interface{}, error) {
+	out, err := s.Upnp.GetCurrentMediaContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	log.Printf("%#v", out)
-	out, err = s.Upnp.GetPositionInfo()
+	out, err = s.Upnp.GetPositionInfoContext(ctx)
 	log.Printf("%#v", out)
 	return out, err
 }
@@ -1,62 +1,179 @@
 package samsung_tv_api
 
 import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stephensli/samsung-tv-api/pkg/device"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/apps"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/keys"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/samsungtvtest"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/store"
+	"github.com/stephensli/samsung-tv-api/pkg/upnp"
+	"github.com/stretchr/testify/assert"
 )
 
 func getSslTestClient() *SamsungTvClient {
 	tv := new(device.DeviceInfo)
 	tv.Type = "samsungtv"
-	return NewSamsungTvWebSocket(tv, 0, false)
+	tv.Ip = "2.2.2.2"
+	return New(tv, WithName("ssl.client"))
 }
 
 func getTestClient() *SamsungTvClient {
 	tv := new(device.DeviceInfo)
 	tv.Type = "samsungtv"
-	return NewSamsungTvWebSocket(tv, 0, false)
+	tv.Ip = "1.1.1.1"
+	return New(tv, WithPort(LegacyPort), WithName("standard.client"))
+}
+
+// getFakeTVClient returns a client for a fake TV, which is closed once the
+// test finishes.
+func getFakeTVClient(t *testing.T) (*SamsungTvClient, *samsungtvtest.Server) {
+	tv := samsungtvtest.NewServer()
+	t.Cleanup(tv.Close)
+
+	cfg := &device.DeviceInfo{Type: "samsungtv", Ip: tv.Host()}
+	client := New(cfg, WithPort(tv.Port()), WithUpnpPort(tv.UpnpPort()))
+	client.Rest.Timeout = time.Second
+	client.AppManager.Policy = apps.LifecyclePolicy{PollInterval: 10 * time.Millisecond}
+
+	return client, tv
 }
 
 func TestFormatWebSocketUrl(t *testing.T) {
-	getTestClient()
+	client := getTestClient()
 
-	// url := client.formatWebSocketUrl("standard.client").String()
-	// name := base64.StdEncoding.EncodeToString([]byte("standard.client"))
+	url := client.formatWebSocketUrl("standard.client").String()
+	name := base64.StdEncoding.EncodeToString([]byte("standard.client"))
 
-	// expected := fmt.Sprintf("ws://1.1.1.1:8001/api/v2/channels/standard.client?name=%s", name)
+	expected := fmt.Sprintf("ws://1.1.1.1:8001/api/v2/channels/standard.client?name=%s", name)
 
-	// assert.Equal(t, expected, url)
+	assert.Equal(t, expected, url)
 }
 
 func TestSslFormatWebSocketUrl(t *testing.T) {
-	// client :=
-	getSslTestClient()
+	client := getSslTestClient()
 
-	// url := client.formatWebSocketUrl("ssl.client").String()
-	// name := base64.StdEncoding.EncodeToString([]byte("ssl.client"))
+	url := client.formatWebSocketUrl("ssl.client").String()
+	name := base64.StdEncoding.EncodeToString([]byte("ssl.client"))
 
-	// expected := fmt.Sprintf("wss://2.2.2.2:8002/api/v2/channels/ssl.client?name=%s&token=", name)
+	expected := fmt.Sprintf("wss://2.2.2.2:8002/api/v2/channels/ssl.client?name=%s&token=", name)
 
-	// assert.Equal(t, expected, url)
+	assert.Equal(t, expected, url)
 }
 
 func TestFormatRestUrl(t *testing.T) {
-	// client :=
-	getTestClient()
+	client := getTestClient()
 
-	// url := client.formatRestUrl("standard.endpoint").String()
-	// expected := "http://1.1.1.1:8001/api/v2/standard.endpoint/"
+	url := client.formatRestUrl("standard.endpoint").String()
+	expected := "http://1.1.1.1:8001/api/v2/standard.endpoint/"
 
-	// assert.Equal(t, expected, url)
+	assert.Equal(t, expected, url)
 }
 
 func TestSslFormatRestUrl(t *testing.T) {
-	// client :=
-	getSslTestClient()
+	client := getSslTestClient()
+
+	url := client.formatRestUrl("ssl.endpoint").String()
+	expected := "https://2.2.2.2:8002/api/v2/ssl.endpoint/"
+
+	assert.Equal(t, expected, url)
+}
+
+func TestPortRemembered(t *testing.T) {
+	cfg := &device.DeviceInfo{Ip: "1.1.1.1", Port: LegacyPort}
+	client := New(cfg, WithUpnpPort(1400))
+
+	assert.Equal(t, LegacyPort, client.Port())
+	assert.Equal(t, "http://1.1.1.1:8001/api/v2/", client.formatRestUrl("").String())
+	assert.Equal(t, "http://1.1.1.1:1400/upnp/control/AVTransport1", client.formatUpnpUrl("AVTransport1").String())
+}
+
+func TestRestClientBuiltOnce(t *testing.T) {
+	client, _ := getFakeTVClient(t)
+
+	// requests share the client, so connections to the TV are kept alive.
+	transport, ok := client.Rest.HTTPClient.Transport.(*http.Transport)
+	assert.True(t, ok)
+	assert.Same(t, client.tlsConfig, transport.TLSClientConfig)
+
+	_, err := client.Rest.GetDeviceInfo()
+	assert.NoError(t, err)
+}
+
+func TestProbeNoAnswer(t *testing.T) {
+	client := New(&device.DeviceInfo{Ip: "127.0.0.1"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := client.Probe(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, 0, client.cfg.Port)
+	assert.Equal(t, DefaultPort, client.Port())
+}
+
+func TestFakeTVConnection(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+	tokens := store.NewMemoryStore()
+	client.TokenStore = tokens
+
+	assert.True(t, client.IsAlive())
+	assert.NoError(t, client.ConnectionSetup())
+	defer client.Disconnect()
+
+	assert.Equal(t, tv.Token(), client.GetToken())
+
+	stored, err := tokens.Token(tv.Host())
+	assert.NoError(t, err)
+	assert.Equal(t, tv.Token(), stored)
+
+	assert.NoError(t, client.VolUp())
+	_, err = client.Vol(30)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []samsungtvtest.KeyPress{{Cmd: "Click", Key: "KEY_VOLUP"}}, tv.Keys())
+	assert.Equal(t, 30, tv.Volume())
+}
+
+func TestKeyParsesNames(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+	client.TokenStore = store.NewMemoryStore()
+
+	assert.NoError(t, client.ConnectionSetup())
+	defer client.Disconnect()
+
+	assert.NoError(t, client.Key("vol down"))
+	assert.ErrorIs(t, client.Key("KEY_NOPE"), keys.ErrUnknownKey)
+
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]samsungtvtest.KeyPress{{Cmd: "Click", Key: "KEY_VOLDOWN"}}, tv.Keys())
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestConvenienceMethodsReturnErrors(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+
+	_, err := client.Vol(200)
+
+	var fault *upnp.SoapFault
+	assert.True(t, errors.As(err, &fault))
+	assert.Equal(t, upnp.ErrorArgumentOutOfRange, fault.ErrorCode)
+
+	tv.Upnp.Close()
 
-	// url := client.formatRestUrl("ssl.endpoint").String()
-	// expected := "https://2.2.2.2:8002/api/v2/ssl.endpoint/"
+	assert.Error(t, client.Stream("http://example.com/video.mp4"))
+	assert.Error(t, client.Play())
+	assert.Error(t, client.Pause())
+	assert.Error(t, client.Next())
+	assert.Error(t, client.Prev())
 
-	// assert.Equal(t, expected, url)
+	_, err = client.Status()
+	assert.Error(t, err)
 }
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
@@ -10,36 +11,61 @@ import (
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
 )
 
+// DefaultTimeout is used for requests whose context carries no deadline.
+const DefaultTimeout = 200 * time.Millisecond
+
 type SamsungRestClient struct {
 	BaseUrl func(string) *url.URL
+	// Timeout bounds requests whose context has no deadline, DefaultTimeout
+	// is used when zero.
+	Timeout time.Duration
+	// HTTPClient is used to send requests when set, otherwise a client using
+	// TLSConfig is created for every request, which does not keep connections
+	// alive.
+	HTTPClient *http.Client
+	// TLSConfig is used to connect to the TV when HTTPClient is not set. The
+	// certificate of the TV is not verified when nil, as it is self-signed.
+	TLSConfig *tls.Config
 }
 
 // makeRestRequest will send a API http call to the given endpoint (base url + endpoint)
 // based on the given method. output will be the binding JSON output of the request.
+// The request is bound to the context, falling back to the client timeout when
+// the context has no deadline.
 //
-// TODO
-//   - support binding to a non 200 response or determine the error message returned and use it in the error response
-func (s *SamsungRestClient) makeRestRequest(endpoint, method string, output interface{}) error {
+// Any unsuccessful response is returned as a *tverrors.HTTPError, wrapping
+// tverrors.ErrUnauthorized or tverrors.ErrAppNotFound where it applies.
+func (s *SamsungRestClient) makeRestRequest(ctx context.Context, endpoint, method string, output interface{}) error {
 	u := s.BaseUrl(endpoint).String()
 
 	log.Printf("rest url %s\n", u)
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		},
-		Timeout: time.Duration(200) * time.Millisecond,
+	if _, ok := ctx.Deadline(); !ok {
+		timeout := s.Timeout
+
+		if timeout == 0 {
+			timeout = DefaultTimeout
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
 
-	req, err := http.NewRequest(strings.ToUpper(method), u, nil)
-	req.Header.Set("Content-Type", "application/json")
+	client := s.httpClient()
+
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), u, nil)
 
 	if err != nil {
 		return err
 	}
 
+	req.Header.Set("Content-Type", "application/json")
+
 	resp, clientErr := client.Do(req)
 
 	if clientErr != nil {
@@ -50,18 +76,73 @@ func (s *SamsungRestClient) makeRestRequest(endpoint, method string, output inte
 		_ = Body.Close()
 	}(resp.Body)
 
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newHTTPError(endpoint, resp)
+	}
+
 	return json.NewDecoder(resp.Body).Decode(&output)
 }
 
+// httpClient returns the HTTPClient when set, otherwise a client connecting
+// with the TLSConfig. The transport of that client is dropped after the
+// request, so keep-alives are disabled to not leave idle connections open.
+func (s *SamsungRestClient) httpClient() *http.Client {
+	if s.HTTPClient != nil {
+		return s.HTTPClient
+	}
+
+	tlsConfig := s.TLSConfig
+
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig:   tlsConfig,
+			DisableKeepAlives: true,
+		},
+	}
+}
+
+// newHTTPError converts an unsuccessful response into a *tverrors.HTTPError,
+// using the message reported by the TV when the body holds one.
+func newHTTPError(endpoint string, resp *http.Response) error {
+	httpErr := &tverrors.HTTPError{StatusCode: resp.StatusCode}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	var errResp ErrorResponse
+	if json.Unmarshal(body, &errResp) == nil && errResp.Message != "" {
+		httpErr.Message = errResp.Message
+	} else {
+		httpErr.Message = strings.TrimSpace(string(body))
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		httpErr.Err = tverrors.ErrUnauthorized
+	case resp.StatusCode == http.StatusNotFound && strings.HasPrefix(endpoint, "applications/"):
+		httpErr.Err = tverrors.ErrAppNotFound
+	}
+
+	return httpErr
+}
+
 // GetDeviceInfo returns the related Tv information via the rest api
 //
 // TODO
 //   - This has to been tested with any bad input, should be regarded as not stable.
 func (s *SamsungRestClient) GetDeviceInfo() (DeviceResponse, error) {
+	return s.GetDeviceInfoContext(context.Background())
+}
+
+// GetDeviceInfoContext is GetDeviceInfo bound to the provided context.
+func (s *SamsungRestClient) GetDeviceInfoContext(ctx context.Context) (DeviceResponse, error) {
 	log.Println("Get device info via rest api")
 
 	output := DeviceResponse{}
-	err := s.makeRestRequest("", "get", &output)
+	err := s.makeRestRequest(ctx, "", "get", &output)
 
 	return output, err
 }
@@ -72,56 +153,49 @@ func (s *SamsungRestClient) GetDeviceInfo() (DeviceResponse, error) {
 // TODO
 //   - This has to been tested with any bad input, should be regarded as not stable.
 func (s *SamsungRestClient) GetApplicationStatus(appId string) (ApplicationResponse, error) {
+	return s.GetApplicationStatusContext(context.Background(), appId)
+}
+
+// GetApplicationStatusContext is GetApplicationStatus bound to the provided context.
+func (s *SamsungRestClient) GetApplicationStatusContext(ctx context.Context, appId string) (ApplicationResponse, error) {
 	log.Println("Getting applications info via rest api")
 
 	var output ApplicationResponse
-	err := s.makeRestRequest(fmt.Sprintf("applications/%s", appId), "get", &output)
+	err := s.makeRestRequest(ctx, fmt.Sprintf("applications/%s", appId), "get", &output)
 
 	return output, err
 }
 
 // RunApplication will tell the TV via the rest api to run a given application
 // by using the provided application id.
-//
-// TODO
-//   - This has to been tested with any bad input, should be regarded as not stable.
 func (s *SamsungRestClient) RunApplication(appId string) (interface{}, error) {
+	return s.RunApplicationContext(context.Background(), appId)
+}
+
+// RunApplicationContext is RunApplication bound to the provided context. The
+// TV accepts the request before the application starts, see apps.Manager to
+// wait for it to become visible.
+func (s *SamsungRestClient) RunApplicationContext(ctx context.Context, appId string) (interface{}, error) {
 	log.Println("Run application via rest api")
 
 	var output interface{}
-	err := s.makeRestRequest(fmt.Sprintf("applications/%s", appId), "post", &output)
-	if err != nil {
-		return output, err
-	}
-	// start watchdog to make sure that app actually has started
-	i := 0
-	for {
-		if i > 10 {
-			// execute app one more time as a last resort
-			err := s.makeRestRequest(fmt.Sprintf("applications/%s", appId), "post", &output)
-			return output, err
-		}
-		appDetails, _ := s.GetApplicationStatus(appId)
-		if appDetails.Visible == true {
-			return output, err
-		}
-		log.Printf("Running %v Visible %v", appDetails.Running, appDetails.Visible)
-		time.Sleep(2 * time.Second)
-		i++
-	}
+	err := s.makeRestRequest(ctx, fmt.Sprintf("applications/%s", appId), "post", &output)
+
+	return output, err
 }
 
 // CloseApplication will tell the TV via the rest api to close a given application
 // by using the provided application id.
-//
-// TODO
-//   - This requires to be tested, it has not been ran to close any applications yet.
-//   - This has to been tested with any bad input, should be regarded as not stable.
 func (s *SamsungRestClient) CloseApplication(appId string) (interface{}, error) {
+	return s.CloseApplicationContext(context.Background(), appId)
+}
+
+// CloseApplicationContext is CloseApplication bound to the provided context.
+func (s *SamsungRestClient) CloseApplicationContext(ctx context.Context, appId string) (interface{}, error) {
 	log.Println("Close application via rest api")
 
 	var output interface{}
-	err := s.makeRestRequest(fmt.Sprintf("applications/%s", appId), "delete", &output)
+	err := s.makeRestRequest(ctx, fmt.Sprintf("applications/%s", appId), "delete", &output)
 
 	return output, err
 }
@@ -133,10 +207,15 @@ func (s *SamsungRestClient) CloseApplication(appId string) (interface{}, error)
 //   - This requires to be tested, it has not been ran to install any applications yet.
 //   - This has to been tested with any bad input, should be regarded as not stable.
 func (s *SamsungRestClient) InstallApplication(appId string) (interface{}, error) {
+	return s.InstallApplicationContext(context.Background(), appId)
+}
+
+// InstallApplicationContext is InstallApplication bound to the provided context.
+func (s *SamsungRestClient) InstallApplicationContext(ctx context.Context, appId string) (interface{}, error) {
 	log.Println("Install application via rest api")
 
 	var output interface{}
-	err := s.makeRestRequest(fmt.Sprintf("applications/%s", appId), "PUT", &output)
+	err := s.makeRestRequest(ctx, fmt.Sprintf("applications/%s", appId), "PUT", &output)
 
 	return output, err
 }
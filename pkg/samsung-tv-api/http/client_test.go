@@ -0,0 +1,82 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/samsungtvtest"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T) (*SamsungRestClient, *samsungtvtest.Server) {
+	tv := samsungtvtest.NewServer()
+	t.Cleanup(tv.Close)
+
+	return &SamsungRestClient{BaseUrl: tv.RestBaseUrl, Timeout: time.Second}, tv
+}
+
+func TestGetDeviceInfo(t *testing.T) {
+	client, tv := newTestClient(t)
+
+	info, err := client.GetDeviceInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, tv.Device["duid"], info.Device.Duid)
+	assert.Equal(t, "false", info.Device.FrameTVSupport)
+}
+
+func TestApplicationLifecycle(t *testing.T) {
+	client, tv := newTestClient(t)
+	tv.AddApp(samsungtvtest.App{ID: "111299001912", Name: "YouTube", AppType: 2})
+
+	status, err := client.GetApplicationStatus("111299001912")
+	assert.NoError(t, err)
+	assert.False(t, status.Visible)
+
+	_, err = client.RunApplicationContext(context.Background(), "111299001912")
+	assert.NoError(t, err)
+
+	app, _ := tv.App("111299001912")
+	assert.True(t, app.Visible)
+
+	_, err = client.CloseApplication("111299001912")
+	assert.NoError(t, err)
+
+	app, _ = tv.App("111299001912")
+	assert.False(t, app.Running)
+}
+
+func TestGetApplicationStatusNotFound(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	_, err := client.GetApplicationStatus("missing")
+	assert.True(t, errors.Is(err, tverrors.ErrAppNotFound))
+
+	var httpErr *tverrors.HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, 404, httpErr.StatusCode)
+}
+
+func TestDefaultTimeout(t *testing.T) {
+	client, tv := newTestClient(t)
+	client.Timeout = 0
+	tv.Latency = 2 * DefaultTimeout
+
+	start := time.Now()
+	_, err := client.GetDeviceInfo()
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), tv.Latency)
+}
+
+func TestRequestCancelled(t *testing.T) {
+	client, tv := newTestClient(t)
+	tv.Latency = time.Second
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	_, err := client.GetDeviceInfoContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
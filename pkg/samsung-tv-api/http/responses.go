@@ -2,10 +2,13 @@ package http
 
 type DeviceResponse struct {
 	Device struct {
-		FrameTVSupport    string `json:"FrameTVSupport"`
-		GamePadSupport    string `json:"GamePadSupport"`
-		ImeSyncedSupport  string `json:"ImeSyncedSupport"`
-		Os                string `json:"OS"`
+		FrameTVSupport   string `json:"FrameTVSupport"`
+		GamePadSupport   string `json:"GamePadSupport"`
+		ImeSyncedSupport string `json:"ImeSyncedSupport"`
+		Os               string `json:"OS"`
+		// PowerState is reported by newer models only, being "on" or
+		// "standby".
+		PowerState        string `json:"PowerState"`
 		TokenAuthSupport  string `json:"TokenAuthSupport"`
 		VoiceSupport      string `json:"VoiceSupport"`
 		CountryCode       string `json:"countryCode"`
@@ -44,3 +47,10 @@ type ApplicationResponse struct {
 	// application running and can the people see it?
 	Visible bool `json:"visible"`
 }
+
+// ErrorResponse is the body the TV responds with when a request fails.
+type ErrorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Status  int    `json:"status"`
+}
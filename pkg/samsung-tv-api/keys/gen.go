@@ -0,0 +1,231 @@
+//go:build ignore
+
+// gen.go generates registry_gen.go from the constants in key.go and the
+// tables of docs/KEY_CODES.md, run through go generate.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	_source = "key.go"
+	_docs   = "../../../docs/KEY_CODES.md"
+	_output = "registry_gen.go"
+)
+
+type key struct {
+	Name        string
+	Code        string
+	Category    string
+	Description string
+	Models      []string
+}
+
+func main() {
+	constants, err := parseConstants(_source)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	documented, err := parseDocs(_docs)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	names := map[string]string{}
+
+	for _, c := range constants {
+		names[c.Code] = c.Name
+	}
+
+	// documented keys come first in the order of the docs, followed by any
+	// constant missing from the docs.
+	var registry []key
+	seen := map[string]bool{}
+
+	for _, k := range documented {
+		if seen[k.Code] {
+			continue
+		}
+
+		k.Name = names[k.Code]
+
+		seen[k.Code] = true
+		registry = append(registry, k)
+	}
+
+	for _, c := range constants {
+		if !seen[c.Code] {
+			seen[c.Code] = true
+			registry = append(registry, c)
+		}
+	}
+
+	if err := os.WriteFile(_output, render(registry), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseConstants returns the exported key constants of the source file in
+// order, the category being the comment heading the group.
+func parseConstants(filename string) ([]key, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var constants []key
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			category := categoryBefore(file.Comments, value.Pos())
+
+			for i, name := range value.Names {
+				if !name.IsExported() || i >= len(value.Values) {
+					continue
+				}
+
+				lit, ok := value.Values[i].(*ast.BasicLit)
+
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+
+				code, err := strconv.Unquote(lit.Value)
+
+				if err != nil {
+					return nil, err
+				}
+
+				constants = append(constants, key{Name: name.Name, Code: code, Category: category})
+			}
+		}
+	}
+
+	return constants, nil
+}
+
+// categoryBefore returns the text of the last comment before pos, such as
+// "Input" for the "// Input Keys" heading.
+func categoryBefore(comments []*ast.CommentGroup, pos token.Pos) string {
+	category := ""
+
+	for _, group := range comments {
+		if group.End() > pos {
+			break
+		}
+
+		if text := strings.TrimSpace(group.Text()); text != "" && !strings.HasPrefix(text, "goland:") {
+			category = text
+		}
+	}
+
+	return strings.TrimSuffix(category, " Keys")
+}
+
+// parseDocs returns the keys listed in the tables of the docs, in order.
+func parseDocs(filename string) ([]key, error) {
+	file, err := os.Open(filename)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	var keys []key
+	category := ""
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "*") && !strings.HasPrefix(line, "**") {
+			category = strings.TrimSuffix(strings.Trim(line, "*"), " Keys")
+			continue
+		}
+
+		if !strings.HasPrefix(line, "KEY_") {
+			continue
+		}
+
+		columns := strings.Split(line, "|")
+		k := key{Code: strings.TrimSpace(columns[0]), Category: category}
+
+		if len(columns) > 1 {
+			k.Description = strings.TrimSpace(columns[1])
+		}
+
+		if len(columns) > 2 {
+			for _, model := range strings.Split(columns[2], ",") {
+				if model = strings.TrimSpace(model); model != "" {
+					k.Models = append(k.Models, model)
+				}
+			}
+		}
+
+		keys = append(keys, k)
+	}
+
+	return keys, scanner.Err()
+}
+
+func render(registry []key) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("// Code generated by gen.go from key.go and docs/KEY_CODES.md; DO NOT EDIT.\n\n")
+	buf.WriteString("package keys\n\n")
+	buf.WriteString("var registry = []Key{\n")
+
+	for _, k := range registry {
+		buf.WriteString("{")
+
+		if k.Name != "" {
+			fmt.Fprintf(&buf, "Name: %q, ", k.Name)
+		}
+
+		fmt.Fprintf(&buf, "Code: %q, Category: %q", k.Code, k.Category)
+
+		if k.Description != "" {
+			fmt.Fprintf(&buf, ", Description: %q", k.Description)
+		}
+
+		if len(k.Models) > 0 {
+			fmt.Fprintf(&buf, ", Models: %#v", k.Models)
+		}
+
+		buf.WriteString("},\n")
+	}
+
+	buf.WriteString("}\n")
+
+	out, err := format.Source(buf.Bytes())
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return out
+}
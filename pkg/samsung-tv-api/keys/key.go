@@ -88,6 +88,7 @@ const (
 	NavigationRight  = "KEY_RIGHT"
 	NavigationReturn = "KEY_RETURN"
 	NavigationEnter  = "KEY_ENTER"
+	NavigationExit   = "KEY_EXIT"
 
 	//
 	// Media Keys
@@ -162,15 +163,15 @@ const (
 	// Picture Mode Keys
 	//
 
-	KEY_PMODE    = "PictureMode"
-	KEY_PANORAMA = "PictureModePanorama"
-	KEY_DYNAMIC  = "PictureModeDynamic"
-	KEY_STANDARD = "PictureModeStandard"
-	KEY_MOVIE1   = "PictureModeMovie"
-	KEY_GAME     = "PictureModeGame"
-	KEY_CUSTOM   = "PictureModeCustom"
-	KEY_EXT9     = "PictureModeMovieAlt"
-	KEY_EXT10    = "PictureModeStandardAlt"
+	KEY_PMODE    = "KEY_PMODE"
+	KEY_PANORAMA = "KEY_PANORAMA"
+	KEY_DYNAMIC  = "KEY_DYNAMIC"
+	KEY_STANDARD = "KEY_STANDARD"
+	KEY_MOVIE1   = "KEY_MOVIE1"
+	KEY_GAME     = "KEY_GAME"
+	KEY_CUSTOM   = "KEY_CUSTOM"
+	KEY_EXT9     = "KEY_EXT9"
+	KEY_EXT10    = "KEY_EXT10"
 
 	//
 	// Menus Keys
@@ -0,0 +1,163 @@
+package keys
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/stephensli/samsung-tv-api/internal/app/samsung-tv-api/fuzzy"
+)
+
+//go:generate go run gen.go
+
+// ErrUnknownKey is returned for a key which is not in the registry.
+var ErrUnknownKey = errors.New("unknown key")
+
+// Key describes a single key code accepted by the TV.
+type Key struct {
+	// Name is the name of the constant of the key in this package, empty for
+	// keys only listed in the docs.
+	Name string `json:"name,omitempty"`
+	// Code is sent to the TV, e.g. KEY_VOLUP.
+	Code        string `json:"code"`
+	Category    string `json:"category"`
+	Description string `json:"description,omitempty"`
+	// Models lists the models known to support the key, when known.
+	Models []string `json:"models,omitempty"`
+}
+
+// aliases are common names of keys matching neither their code, constant or
+// description.
+var aliases = map[string]string{
+	"back":   NavigationReturn,
+	"ok":     NavigationEnter,
+	"select": NavigationEnter,
+}
+
+var (
+	byCode = map[string]int{}
+	byName = map[string]int{}
+)
+
+func init() {
+	for i, k := range registry {
+		byCode[k.Code] = i
+
+		if k.Name != "" {
+			byName[k.Name] = i
+		}
+	}
+}
+
+// All returns every key of the registry, grouped by category.
+func All() []Key {
+	all := make([]Key, len(registry))
+	copy(all, registry)
+
+	return all
+}
+
+// Lookup returns the key with the code, e.g. KEY_VOLUP, or the constant name,
+// e.g. VolumeUp.
+func Lookup(key string) (Key, bool) {
+	if i, ok := byCode[key]; ok {
+		return registry[i], true
+	}
+
+	if i, ok := byName[key]; ok {
+		return registry[i], true
+	}
+
+	return Key{}, false
+}
+
+// IsKey returns true if the key is the code of a key in the registry.
+func IsKey(key string) bool {
+	_, ok := byCode[key]
+	return ok
+}
+
+// Parse returns the key matching a loosely written name such as "vol up",
+// "Volume Up" or "KEY_VOLUP", ignoring case and separators and allowing small
+// typos. ErrUnknownKey is returned when nothing or more than one key matches.
+func Parse(name string) (Key, error) {
+	if k, ok := Lookup(name); ok {
+		return k, nil
+	}
+
+	wanted := normalize(name)
+
+	if wanted == "" {
+		return Key{}, fmt.Errorf("%w %q", ErrUnknownKey, name)
+	}
+
+	// exact matches, preferring the code over the name over the description.
+	for _, field := range []func(Key) string{codeOf, nameOf, descriptionOf} {
+		for _, k := range registry {
+			if normalize(field(k)) == wanted {
+				return k, nil
+			}
+		}
+	}
+
+	if code, ok := aliases[wanted]; ok {
+		return registry[byCode[code]], nil
+	}
+
+	// then the keys closest to the name, as long as only one key is.
+	if len(wanted) < 3 {
+		return Key{}, fmt.Errorf("%w %q", ErrUnknownKey, name)
+	}
+
+	best := max(1, len(wanted)/4)
+	var matches []Key
+
+	for _, k := range registry {
+		distance := best + 1
+
+		for _, field := range []func(Key) string{codeOf, nameOf, descriptionOf} {
+			if candidate := normalize(field(k)); candidate != "" {
+				distance = min(distance, fuzzy.Distance(wanted, candidate))
+			}
+		}
+
+		if distance < best {
+			best, matches = distance, nil
+		}
+
+		if distance == best {
+			matches = append(matches, k)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Key{}, fmt.Errorf("%w %q", ErrUnknownKey, name)
+	case 1:
+		return matches[0], nil
+	}
+
+	codes := make([]string, len(matches))
+
+	for i, k := range matches {
+		codes[i] = k.Code
+	}
+
+	return Key{}, fmt.Errorf("%w %q, did you mean %s", ErrUnknownKey, name, strings.Join(codes, " or "))
+}
+
+func codeOf(k Key) string        { return k.Code }
+func nameOf(k Key) string        { return k.Name }
+func descriptionOf(k Key) string { return k.Description }
+
+// normalize lower cases the name, removing the KEY_ prefix and anything other
+// than letters and digits.
+func normalize(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	for _, prefix := range []string{"key_", "key-", "key "} {
+		name = strings.TrimPrefix(name, prefix)
+	}
+
+	return fuzzy.Normalize(name)
+}
@@ -0,0 +1,250 @@
+// Code generated by gen.go from key.go and docs/KEY_CODES.md; DO NOT EDIT.
+
+package keys
+
+var registry = []Key{
+	{Name: "PowerOff", Code: "KEY_POWEROFF", Category: "Power", Description: "PowerOFF", Models: []string{"pre-2016"}},
+	{Name: "PowerOn", Code: "KEY_POWERON", Category: "Power", Description: "PowerOn", Models: []string{"pre-2016"}},
+	{Name: "Power", Code: "KEY_POWER", Category: "Power", Description: "PowerToggle", Models: []string{"2016+"}},
+	{Name: "Source", Code: "KEY_SOURCE", Category: "Input", Description: "Source"},
+	{Name: "Component1", Code: "KEY_COMPONENT1", Category: "Input", Description: "Component1"},
+	{Name: "Component2", Code: "KEY_COMPONENT2", Category: "Input", Description: "Component2"},
+	{Name: "AV1", Code: "KEY_AV1", Category: "Input", Description: "AV1"},
+	{Name: "AV2", Code: "KEY_AV2", Category: "Input", Description: "AV2"},
+	{Name: "AV3", Code: "KEY_AV3", Category: "Input", Description: "AV3"},
+	{Name: "SVideo1", Code: "KEY_SVIDEO1", Category: "Input", Description: "SVideo1"},
+	{Name: "SVideo2", Code: "KEY_SVIDEO2", Category: "Input", Description: "SVideo2"},
+	{Name: "SVideo3", Code: "KEY_SVIDEO3", Category: "Input", Description: "SVideo3"},
+	{Name: "HDMI", Code: "KEY_HDMI", Category: "Input", Description: "HDMI"},
+	{Code: "KEY_HDMI1", Category: "Input", Description: "HDMI1"},
+	{Code: "KEY_HDMI2", Category: "Input", Description: "HDMI2"},
+	{Code: "KEY_HDMI3", Category: "Input", Description: "HDMI3"},
+	{Code: "KEY_HDMI4", Category: "Input", Description: "HDMI4"},
+	{Name: "FMRadio", Code: "KEY_FM_RADIO", Category: "Input", Description: "FMRadio"},
+	{Name: "DVI", Code: "KEY_DVI", Category: "Input", Description: "DVI"},
+	{Name: "DVR", Code: "KEY_DVR", Category: "Input", Description: "DVR"},
+	{Name: "TV", Code: "KEY_TV", Category: "Input", Description: "TV"},
+	{Name: "AnalogTV", Code: "KEY_ANTENA", Category: "Input", Description: "AnalogTV"},
+	{Name: "DigitalTV", Code: "KEY_DTV", Category: "Input", Description: "DigitalTV"},
+	{Name: "AmbientMode", Code: "KEY_AMBIENT", Category: "Input", Description: "AmbientMode", Models: []string{"2018+ QLED", "The Frame"}},
+	{Name: "Key1", Code: "KEY_1", Category: "Number", Description: "Key1"},
+	{Name: "Key2", Code: "KEY_2", Category: "Number", Description: "Key2"},
+	{Name: "Key3", Code: "KEY_3", Category: "Number", Description: "Key3"},
+	{Name: "Key4", Code: "KEY_4", Category: "Number", Description: "Key4"},
+	{Name: "Key5", Code: "KEY_5", Category: "Number", Description: "Key5"},
+	{Name: "Key6", Code: "KEY_6", Category: "Number", Description: "Key6"},
+	{Name: "Key7", Code: "KEY_7", Category: "Number", Description: "Key7"},
+	{Name: "Key8", Code: "KEY_8", Category: "Number", Description: "Key8"},
+	{Name: "Key9", Code: "KEY_9", Category: "Number", Description: "Key9"},
+	{Name: "Key0", Code: "KEY_0", Category: "Number", Description: "Key0"},
+	{Name: "ThreeD", Code: "KEY_PANNEL_CHDOWN", Category: "Misc", Description: "3D"},
+	{Name: "AnyNetPlus", Code: "KEY_ANYNET", Category: "Misc", Description: "AnyNet+"},
+	{Name: "EnergySaving", Code: "KEY_ESAVING", Category: "Misc", Description: "EnergySaving"},
+	{Name: "SleepTimer", Code: "KEY_SLEEP", Category: "Misc", Description: "SleepTimer"},
+	{Name: "DTVSignal", Code: "KEY_DTV_SIGNAL", Category: "Misc", Description: "DTVSignal"},
+	{Name: "ChannelUp", Code: "KEY_CHUP", Category: "Channel", Description: "ChannelUp"},
+	{Name: "ChannelDown", Code: "KEY_CHDOWN", Category: "Channel", Description: "ChannelDown"},
+	{Name: "PreviousChannel", Code: "KEY_PRECH", Category: "Channel", Description: "PreviousChannel"},
+	{Name: "FavoriteChannels", Code: "KEY_FAVCH", Category: "Channel", Description: "FavoriteChannels"},
+	{Name: "ChannelList", Code: "KEY_CH_LIST", Category: "Channel", Description: "ChannelList"},
+	{Name: "AutoProgram", Code: "KEY_AUTO_PROGRAM", Category: "Channel", Description: "AutoProgram"},
+	{Name: "MagicChannel", Code: "KEY_MAGIC_CHANNEL", Category: "Channel", Description: "MagicChannel"},
+	{Name: "VolumeUp", Code: "KEY_VOLUP", Category: "Volume", Description: "VolumeUp"},
+	{Name: "VolumeDown", Code: "KEY_VOLDOWN", Category: "Volume", Description: "VolumeDown"},
+	{Name: "Mute", Code: "KEY_MUTE", Category: "Volume", Description: "Mute"},
+	{Name: "NavigationUp", Code: "KEY_UP", Category: "Direction", Description: "NavigationUp"},
+	{Name: "NavigationDown", Code: "KEY_DOWN", Category: "Direction", Description: "NavigationDown"},
+	{Name: "NavigationLeft", Code: "KEY_LEFT", Category: "Direction", Description: "NavigationLeft"},
+	{Name: "NavigationRight", Code: "KEY_RIGHT", Category: "Direction", Description: "NavigationRight"},
+	{Name: "NavigationReturn", Code: "KEY_RETURN", Category: "Direction", Description: "NavigationReturn/Back"},
+	{Name: "NavigationEnter", Code: "KEY_ENTER", Category: "Direction", Description: "NavigationEnter"},
+	{Name: "NavigationExit", Code: "KEY_EXIT", Category: "Direction", Description: "NavigationExit"},
+	{Name: "Rewind", Code: "KEY_REWIND", Category: "Media", Description: "Rewind"},
+	{Name: "Stop", Code: "KEY_STOP", Category: "Media", Description: "Stop"},
+	{Name: "Play", Code: "KEY_PLAY", Category: "Media", Description: "Play"},
+	{Name: "FastForward", Code: "KEY_FF", Category: "Media", Description: "FastForward"},
+	{Name: "Record", Code: "KEY_REC", Category: "Media", Description: "Record"},
+	{Name: "Pause", Code: "KEY_PAUSE", Category: "Media", Description: "Pause"},
+	{Name: "Live", Code: "KEY_LIVE", Category: "Media", Description: "Live"},
+	{Code: "KEY_QUICK_REPLAY", Category: "Media", Description: "fnKEY_QUICK_REPLAY"},
+	{Code: "KEY_STILL_PICTURE", Category: "Media", Description: "fnKEY_STILL_PICTURE"},
+	{Code: "KEY_INSTANT_REPLAY", Category: "Media", Description: "fnKEY_INSTANT_REPLAY"},
+	{Name: "PIPOnOff", Code: "KEY_PIP_ONOFF", Category: "Picture in Picture", Description: "PIPOn/Off"},
+	{Name: "PIPSwap", Code: "KEY_PIP_SWAP", Category: "Picture in Picture", Description: "PIPSwap"},
+	{Name: "PIPSize", Code: "KEY_PIP_SIZE", Category: "Picture in Picture", Description: "PIPSize"},
+	{Name: "PIPChannelUp", Code: "KEY_PIP_CHUP", Category: "Picture in Picture", Description: "PIPChannelUp"},
+	{Name: "PIPChannelDown", Code: "KEY_PIP_CHDOWN", Category: "Picture in Picture", Description: "PIPChannelDown"},
+	{Name: "PIPSmall", Code: "KEY_AUTO_ARC_PIP_SMALL", Category: "Picture in Picture", Description: "PIPSmall"},
+	{Name: "PIPWide", Code: "KEY_AUTO_ARC_PIP_WIDE", Category: "Picture in Picture", Description: "PIPWide"},
+	{Name: "PIPBottomRight", Code: "KEY_AUTO_ARC_PIP_RIGHT_BOTTOM", Category: "Picture in Picture", Description: "PIPBottomRight"},
+	{Name: "PIPSourceChange", Code: "KEY_AUTO_ARC_PIP_SOURCE_CHANGE", Category: "Picture in Picture", Description: "PIPSourceChange"},
+	{Name: "PIPScan", Code: "KEY_PIP_SCAN", Category: "Picture in Picture", Description: "PIPScan"},
+	{Name: "VCRMode", Code: "KEY_VCR_MODE", Category: "Modes", Description: "VCRMode"},
+	{Name: "CATVMode", Code: "KEY_CATV_MODE", Category: "Modes", Description: "CATVMode"},
+	{Name: "DSSMode", Code: "KEY_DSS_MODE", Category: "Modes", Description: "DSSMode"},
+	{Name: "TVMode", Code: "KEY_TV_MODE", Category: "Modes", Description: "TVMode"},
+	{Name: "DVDMode", Code: "KEY_DVD_MODE", Category: "Modes", Description: "DVDMode"},
+	{Name: "STBMode", Code: "KEY_STB_MODE", Category: "Modes", Description: "STBMode"},
+	{Name: "PCMode", Code: "KEY_PCMODE", Category: "Modes", Description: "PCMode"},
+	{Name: "Green", Code: "KEY_GREEN", Category: "Color", Description: "Green"},
+	{Name: "Yellow", Code: "KEY_YELLOW", Category: "Color", Description: "Yellow"},
+	{Name: "Cyan", Code: "KEY_CYAN", Category: "Color", Description: "Cyan"},
+	{Name: "Red", Code: "KEY_RED", Category: "Color", Description: "Red"},
+	{Name: "TeletextMix", Code: "KEY_TTX_MIX", Category: "Teletext", Description: "TeletextMix"},
+	{Name: "TeletextSubface", Code: "KEY_TTX_SUBFACE", Category: "Teletext", Description: "TeletextSubface"},
+	{Name: "AspectRatio", Code: "KEY_ASPECT", Category: "Aspect Ratio", Description: "AspectRatio"},
+	{Name: "PictureSize", Code: "KEY_PICTURE_SIZE", Category: "Aspect Ratio", Description: "PictureSize"},
+	{Name: "AspectRatio43", Code: "KEY_4_3", Category: "Aspect Ratio", Description: "AspectRatio4:3"},
+	{Name: "AspectRatio169", Code: "KEY_16_9", Category: "Aspect Ratio", Description: "AspectRatio16:9"},
+	{Name: "AspectRatio34Alt", Code: "KEY_EXT14", Category: "Aspect Ratio", Description: "AspectRatio3:4(Alt)"},
+	{Name: "AspectRatio169Alt", Code: "KEY_EXT15", Category: "Aspect Ratio", Description: "AspectRatio16:9(Alt)"},
+	{Name: "KEY_PMODE", Code: "KEY_PMODE", Category: "Picture Mode", Description: "PictureMode"},
+	{Name: "KEY_PANORAMA", Code: "KEY_PANORAMA", Category: "Picture Mode", Description: "PictureModePanorama"},
+	{Name: "KEY_DYNAMIC", Code: "KEY_DYNAMIC", Category: "Picture Mode", Description: "PictureModeDynamic"},
+	{Name: "KEY_STANDARD", Code: "KEY_STANDARD", Category: "Picture Mode", Description: "PictureModeStandard"},
+	{Name: "KEY_MOVIE1", Code: "KEY_MOVIE1", Category: "Picture Mode", Description: "PictureModeMovie"},
+	{Name: "KEY_GAME", Code: "KEY_GAME", Category: "Picture Mode", Description: "PictureModeGame"},
+	{Name: "KEY_CUSTOM", Code: "KEY_CUSTOM", Category: "Picture Mode", Description: "PictureModeCustom"},
+	{Name: "KEY_EXT9", Code: "KEY_EXT9", Category: "Picture Mode", Description: "PictureModeMovie(Alt)"},
+	{Name: "KEY_EXT10", Code: "KEY_EXT10", Category: "Picture Mode", Description: "PictureModeStandard(Alt)"},
+	{Name: "Menu", Code: "KEY_MENU", Category: "Menus", Description: "Menu"},
+	{Name: "TopMenu", Code: "KEY_TOPMENU", Category: "Menus", Description: "TopMenu"},
+	{Name: "Tools", Code: "KEY_TOOLS", Category: "Menus", Description: "Tools"},
+	{Name: "Home", Code: "KEY_HOME", Category: "Menus", Description: "Home"},
+	{Name: "Contents", Code: "KEY_CONTENTS", Category: "Menus", Description: "Contents"},
+	{Name: "Guide", Code: "KEY_GUIDE", Category: "Menus", Description: "Guide"},
+	{Name: "DiscMenu", Code: "KEY_DISC_MENU", Category: "Menus", Description: "DiscMenu"},
+	{Name: "DVRMenu", Code: "KEY_DVR_MENU", Category: "Menus", Description: "DVRMenu"},
+	{Name: "Help", Code: "KEY_HELP", Category: "Menus", Description: "Help"},
+	{Name: "Info", Code: "KEY_INFO", Category: "OSD", Description: "Info"},
+	{Name: "Caption", Code: "KEY_CAPTION", Category: "OSD", Description: "Caption"},
+	{Name: "ClockDisplay", Code: "KEY_CLOCK_DISPLAY", Category: "OSD", Description: "ClockDisplay"},
+	{Name: "SetupClock", Code: "KEY_SETUP_CLOCK_TIMER", Category: "OSD", Description: "SetupClock"},
+	{Name: "Subtitle", Code: "KEY_SUB_TITLE", Category: "OSD", Description: "Subtitle"},
+	{Name: "ZoomMove", Code: "KEY_ZOOM_MOVE", Category: "Zoom", Description: "ZoomMove"},
+	{Name: "ZoomIn", Code: "KEY_ZOOM_IN", Category: "Zoom", Description: "ZoomIn"},
+	{Name: "ZoomOut", Code: "KEY_ZOOM_OUT", Category: "Zoom", Description: "ZoomOut"},
+	{Name: "Zoom1", Code: "KEY_ZOOM1", Category: "Zoom", Description: "Zoom1"},
+	{Name: "Zoom2", Code: "KEY_ZOOM2", Category: "Zoom", Description: "Zoom2"},
+	{Name: "WheelLeft", Code: "KEY_WHEEL_LEFT", Category: "Other", Description: "WheelLeft"},
+	{Name: "WheelRight", Code: "KEY_WHEEL_RIGHT", Category: "Other", Description: "WheelRight"},
+	{Name: "AddOrDel", Code: "KEY_ADDDEL", Category: "Other", Description: "Add/Del"},
+	{Name: "Plus100", Code: "KEY_PLUS100", Category: "Other", Description: "Plus100"},
+	{Name: "AD", Code: "KEY_AD", Category: "Other", Description: "AD"},
+	{Name: "Link", Code: "KEY_LINK", Category: "Other", Description: "Link"},
+	{Name: "Turbo", Code: "KEY_TURBO", Category: "Other", Description: "Turbo"},
+	{Name: "Convergence", Code: "KEY_CONVERGENCE", Category: "Other", Description: "Convergence"},
+	{Name: "DeviceConnect", Code: "KEY_DEVICE_CONNECT", Category: "Other", Description: "DeviceConnect"},
+	{Name: "Key11", Code: "KEY_11", Category: "Other", Description: "Key11"},
+	{Name: "Key12", Code: "KEY_12", Category: "Other", Description: "Key12"},
+	{Name: "KeyFactory", Code: "KEY_FACTORY", Category: "Other", Description: "KeyFactory"},
+	{Name: "Key3SPEED", Code: "KEY_3SPEED", Category: "Other", Description: "Key3SPEED"},
+	{Name: "KeyRSURF", Code: "KEY_RSURF", Category: "Other", Description: "KeyRSURF"},
+	{Name: "FF_", Code: "KEY_FF_", Category: "Other", Description: "FF_"},
+	{Name: "REWIND_", Code: "KEY_REWIND_", Category: "Other", Description: "REWIND_"},
+	{Name: "Angle", Code: "KEY_ANGLE", Category: "Other", Description: "Angle"},
+	{Name: "Reserved1", Code: "KEY_RESERVED1", Category: "Other", Description: "Reserved1"},
+	{Name: "Program", Code: "KEY_PROGRAM", Category: "Other", Description: "Program"},
+	{Name: "Bookmark", Code: "KEY_BOOKMARK", Category: "Other", Description: "Bookmark"},
+	{Name: "Print", Code: "KEY_PRINT", Category: "Other", Description: "Print"},
+	{Name: "Clear", Code: "KEY_CLEAR", Category: "Other", Description: "Clear"},
+	{Name: "VChip", Code: "KEY_VCHIP", Category: "Other", Description: "VChip"},
+	{Name: "Repeat", Code: "KEY_REPEAT", Category: "Other", Description: "Repeat"},
+	{Name: "Door", Code: "KEY_DOOR", Category: "Other", Description: "Door"},
+	{Name: "Open", Code: "KEY_OPEN", Category: "Other", Description: "Open"},
+	{Name: "DMA", Code: "KEY_DMA", Category: "Other", Description: "DMA"},
+	{Name: "MTS", Code: "KEY_MTS", Category: "Other", Description: "MTS"},
+	{Name: "DNIe", Code: "KEY_DNIe", Category: "Other", Description: "DNIe"},
+	{Name: "SRS", Code: "KEY_SRS", Category: "Other", Description: "SRS"},
+	{Name: "ConvertAudioMainOrSub", Code: "KEY_CONVERT_AUDIO_MAINSUB", Category: "Other", Description: "ConvertAudioMain/Sub"},
+	{Name: "MDC", Code: "KEY_MDC", Category: "Other", Description: "MDC"},
+	{Name: "SoundEffect", Code: "KEY_SEFFECT", Category: "Other", Description: "SoundEffect"},
+	{Name: "PERPECTFocus", Code: "KEY_PERPECT_FOCUS", Category: "Other", Description: "PERPECTFocus"},
+	{Name: "CallerID", Code: "KEY_CALLER_ID", Category: "Other", Description: "CallerID"},
+	{Name: "Scale", Code: "KEY_SCALE", Category: "Other", Description: "Scale"},
+	{Name: "MagicBright", Code: "KEY_MAGIC_BRIGHT", Category: "Other", Description: "MagicBright"},
+	{Name: "WLink", Code: "KEY_W_LINK", Category: "Other", Description: "WLink"},
+	{Name: "DTVLink", Code: "KEY_DTV_LINK", Category: "Other", Description: "DTVLink"},
+	{Name: "ApplicationList", Code: "KEY_APP_LIST", Category: "Other", Description: "ApplicationList"},
+	{Name: "BackMHP", Code: "KEY_BACK_MHP", Category: "Other", Description: "BackMHP"},
+	{Name: "AlternateMHP", Code: "KEY_ALT_MHP", Category: "Other", Description: "AlternateMHP"},
+	{Name: "DNSe", Code: "KEY_DNSe", Category: "Other", Description: "DNSe"},
+	{Name: "RSS", Code: "KEY_RSS", Category: "Other", Description: "RSS"},
+	{Name: "Entertainment", Code: "KEY_ENTERTAINMENT", Category: "Other", Description: "Entertainment"},
+	{Name: "IDInput", Code: "KEY_ID_INPUT", Category: "Other", Description: "IDInput"},
+	{Name: "IDSetup", Code: "KEY_ID_SETUP", Category: "Other", Description: "IDSetup"},
+	{Name: "AnyView", Code: "KEY_ANYVIEW", Category: "Other", Description: "AnyView"},
+	{Name: "MS", Code: "KEY_MS", Category: "Other", Description: "MS"},
+	{Name: "More", Code: "KEY_MORE", Category: "Other"},
+	{Name: "Mic", Code: "KEY_MIC", Category: "Other"},
+	{Name: "NineSeparate", Code: "KEY_NINE_SEPERATE", Category: "Other"},
+	{Name: "AutoFormat", Code: "KEY_AUTO_FORMAT", Category: "Other", Description: "AutoFormat"},
+	{Name: "DNET", Code: "KEY_DNET", Category: "Other", Description: "DNET"},
+	{Name: "AUTO_ARC_C_FORCE_AGING", Code: "KEY_AUTO_ARC_C_FORCE_AGING", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_CAPTION_ENG", Code: "KEY_AUTO_ARC_CAPTION_ENG", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_USBJACK_INSPECT", Code: "KEY_AUTO_ARC_USBJACK_INSPECT", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_RESET", Code: "KEY_AUTO_ARC_RESET", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_LNA_ON", Code: "KEY_AUTO_ARC_LNA_ON", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_LNA_OFF", Code: "KEY_AUTO_ARC_LNA_OFF", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_ANYNET_MODE_OK", Code: "KEY_AUTO_ARC_ANYNET_MODE_OK", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_ANYNET_AUTO_START", Code: "KEY_AUTO_ARC_ANYNET_AUTO_START", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_CAPTION_ON", Code: "KEY_AUTO_ARC_CAPTION_ON", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_CAPTION_OFF", Code: "KEY_AUTO_ARC_CAPTION_OFF", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_PIP_DOUBLE", Code: "KEY_AUTO_ARC_PIP_DOUBLE", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_PIP_LARGE", Code: "KEY_AUTO_ARC_PIP_LARGE", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_PIP_LEFT_TOP", Code: "KEY_AUTO_ARC_PIP_LEFT_TOP", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_PIP_RIGHT_TOP", Code: "KEY_AUTO_ARC_PIP_RIGHT_TOP", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_PIP_LEFT_BOTTOM", Code: "KEY_AUTO_ARC_PIP_LEFT_BOTTOM", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_PIP_CH_CHANGE", Code: "KEY_AUTO_ARC_PIP_CH_CHANGE", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_AUTOCOLOR_SUCCESS", Code: "KEY_AUTO_ARC_AUTOCOLOR_SUCCESS", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_AUTOCOLOR_FAIL", Code: "KEY_AUTO_ARC_AUTOCOLOR_FAIL", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_JACK_IDENT", Code: "KEY_AUTO_ARC_JACK_IDENT", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_CAPTION_KOR", Code: "KEY_AUTO_ARC_CAPTION_KOR", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_ANTENNA_AIR", Code: "KEY_AUTO_ARC_ANTENNA_AIR", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_ANTENNA_CABLE", Code: "KEY_AUTO_ARC_ANTENNA_CABLE", Category: "Auto Arc"},
+	{Name: "AUTO_ARC_ANTENNA_SATELLITE", Code: "KEY_AUTO_ARC_ANTENNA_SATELLITE", Category: "Auto Arc"},
+	{Name: "PANNEL_POWER", Code: "KEY_PANNEL_POWER", Category: "Panel"},
+	{Name: "PANNEL_CHUP", Code: "KEY_PANNEL_CHUP", Category: "Panel"},
+	{Name: "PANNEL_VOLUP", Code: "KEY_PANNEL_VOLUP", Category: "Panel"},
+	{Name: "PANNEL_VOLDOW", Code: "KEY_PANNEL_VOLDOW", Category: "Panel"},
+	{Name: "PANNEL_ENTER", Code: "KEY_PANNEL_ENTER", Category: "Panel"},
+	{Name: "PANNEL_MENU", Code: "KEY_PANNEL_MENU", Category: "Panel"},
+	{Name: "PANNEL_SOURCE", Code: "KEY_PANNEL_SOURCE", Category: "Panel"},
+	{Code: "KEY_EXT1", Category: "Extended"},
+	{Code: "KEY_EXT2", Category: "Extended"},
+	{Code: "KEY_EXT3", Category: "Extended"},
+	{Code: "KEY_EXT4", Category: "Extended"},
+	{Code: "KEY_EXT5", Category: "Extended"},
+	{Code: "KEY_EXT6", Category: "Extended"},
+	{Code: "KEY_EXT7", Category: "Extended"},
+	{Code: "KEY_EXT8", Category: "Extended"},
+	{Code: "KEY_EXT11", Category: "Extended"},
+	{Code: "KEY_EXT12", Category: "Extended"},
+	{Code: "KEY_EXT13", Category: "Extended"},
+	{Code: "KEY_EXT16", Category: "Extended"},
+	{Code: "KEY_EXT17", Category: "Extended"},
+	{Code: "KEY_EXT18", Category: "Extended"},
+	{Code: "KEY_EXT19", Category: "Extended"},
+	{Code: "KEY_EXT20", Category: "Extended"},
+	{Code: "KEY_EXT21", Category: "Extended"},
+	{Code: "KEY_EXT22", Category: "Extended"},
+	{Code: "KEY_EXT23", Category: "Extended"},
+	{Code: "KEY_EXT24", Category: "Extended"},
+	{Code: "KEY_EXT25", Category: "Extended"},
+	{Code: "KEY_EXT26", Category: "Extended"},
+	{Code: "KEY_EXT27", Category: "Extended"},
+	{Code: "KEY_EXT28", Category: "Extended"},
+	{Code: "KEY_EXT29", Category: "Extended"},
+	{Code: "KEY_EXT30", Category: "Extended"},
+	{Code: "KEY_EXT31", Category: "Extended"},
+	{Code: "KEY_EXT32", Category: "Extended"},
+	{Code: "KEY_EXT33", Category: "Extended"},
+	{Code: "KEY_EXT34", Category: "Extended"},
+	{Code: "KEY_EXT35", Category: "Extended"},
+	{Code: "KEY_EXT36", Category: "Extended"},
+	{Code: "KEY_EXT37", Category: "Extended"},
+	{Code: "KEY_EXT38", Category: "Extended"},
+	{Code: "KEY_EXT39", Category: "Extended"},
+	{Code: "KEY_EXT40", Category: "Extended"},
+	{Code: "KEY_EXT41", Category: "Extended"},
+}
@@ -0,0 +1,67 @@
+package keys
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	k, ok := Lookup("KEY_VOLUP")
+	assert.True(t, ok)
+	assert.Equal(t, Key{Name: "VolumeUp", Code: VolumeUp, Category: "Volume", Description: "VolumeUp"}, k)
+
+	k, ok = Lookup("AmbientMode")
+	assert.True(t, ok)
+	assert.Equal(t, AmbientMode, k.Code)
+	assert.NotEmpty(t, k.Models)
+
+	_, ok = Lookup("KEY_VOLUMEUP")
+	assert.False(t, ok)
+}
+
+func TestAllHoldsEveryConstant(t *testing.T) {
+	all := All()
+	codes := map[string]bool{}
+
+	for _, k := range all {
+		assert.False(t, codes[k.Code], "%s is listed twice", k.Code)
+		codes[k.Code] = true
+	}
+
+	for _, code := range []string{PowerOff, NavigationExit, KEY_PMODE, PANNEL_ENTER, "KEY_HDMI1"} {
+		assert.True(t, codes[code], code)
+	}
+
+	all[0].Code = "changed"
+	assert.Equal(t, PowerOff, All()[0].Code)
+}
+
+func TestParse(t *testing.T) {
+	for name, code := range map[string]string{
+		"vol up":       VolumeUp,
+		"Volume Up":    VolumeUp,
+		"KEY_VOLUMEUP": VolumeUp,
+		"key_volup":    VolumeUp,
+		"ch-down":      ChannelDown,
+		"back":         NavigationReturn,
+		"hdmi 2":       "KEY_HDMI2",
+		"chanel list":  ChannelList,
+		"sorce":        Source,
+	} {
+		k, err := Parse(name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, code, k.Code, name)
+	}
+}
+
+func TestParseUnknown(t *testing.T) {
+	for _, name := range []string{"", "netflix", "zz"} {
+		_, err := Parse(name)
+		assert.ErrorIs(t, err, ErrUnknownKey, name)
+	}
+
+	_, err := Parse("hdmi5")
+	assert.ErrorIs(t, err, ErrUnknownKey)
+	assert.Contains(t, err.Error(), "did you mean")
+}
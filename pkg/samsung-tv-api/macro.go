@@ -0,0 +1,99 @@
+package samsung_tv_api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/macro"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/websocket"
+)
+
+// RunMacro runs every step of the macro against the TV in order, see the
+// macro package for the format of macros. Applications are launched by name or
+// id through LaunchApp.
+func (s *SamsungTvClient) RunMacro(ctx context.Context, m macro.Macro) error {
+	return m.Run(ctx, macroRemote{SamsungWebsocket: &s.Websocket, client: s})
+}
+
+// macroRemote runs macros against the websocket, launching applications
+// through the catalogue of the client.
+type macroRemote struct {
+	*websocket.SamsungWebsocket
+	client *SamsungTvClient
+}
+
+func (r macroRemote) LaunchApp(ctx context.Context, name string) error {
+	_, err := r.client.LaunchApp(ctx, name)
+	return err
+}
+
+// ErrMacroNotFound is returned when the device has no macro with the name.
+var ErrMacroNotFound = errors.New("macro not found")
+
+// Macros returns the names of the macros stored for the device, sorted.
+func (s *SamsungTvClient) Macros() []string {
+	names := make([]string, 0, len(s.cfg.Macros))
+
+	for name := range s.cfg.Macros {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Macro returns the macro stored for the device with the name.
+func (s *SamsungTvClient) Macro(name string) (macro.Macro, error) {
+	raw, ok := s.cfg.Macros[name]
+
+	if !ok {
+		return macro.Macro{}, fmt.Errorf("%w: %s", ErrMacroNotFound, name)
+	}
+
+	return macro.ParseJSON(raw)
+}
+
+// SaveMacro stores the macro for the device with the name, replacing any macro
+// with the same name. The device configuration must be persisted by the caller.
+func (s *SamsungTvClient) SaveMacro(name string, m macro.Macro) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(m.Steps)
+
+	if err != nil {
+		return err
+	}
+
+	if s.cfg.Macros == nil {
+		s.cfg.Macros = map[string]json.RawMessage{}
+	}
+
+	s.cfg.Macros[name] = raw
+	return nil
+}
+
+// DeleteMacro removes the macro stored for the device with the name.
+func (s *SamsungTvClient) DeleteMacro(name string) error {
+	if _, ok := s.cfg.Macros[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrMacroNotFound, name)
+	}
+
+	delete(s.cfg.Macros, name)
+	return nil
+}
+
+// RunNamedMacro runs the macro stored for the device with the name.
+func (s *SamsungTvClient) RunNamedMacro(ctx context.Context, name string) error {
+	m, err := s.Macro(name)
+
+	if err != nil {
+		return err
+	}
+
+	return s.RunMacro(ctx, m)
+}
@@ -0,0 +1,288 @@
+package macro
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/keys"
+)
+
+// This package covers scripting the remote, a macro being a sequence of steps
+// such as key clicks, waits and typing text. Macros are written either in the
+// text format, e.g. "Home, Down x3, Right, Enter, wait 2s", or as JSON.
+
+// Action is what a single step of a macro does.
+type Action string
+
+const (
+	// Click clicks the Key, Times times.
+	Click Action = "click"
+	// Press presses the Key without releasing it.
+	Press Action = "press"
+	// Release releases the previously pressed Key.
+	Release Action = "release"
+	// Hold presses the Key for the Duration before releasing it.
+	Hold Action = "hold"
+	// Wait waits for the Duration.
+	Wait Action = "wait"
+	// Text sends the Text to the focused input field.
+	Text Action = "text"
+	// App launches the application with the App name or id.
+	App Action = "app"
+)
+
+// ErrUnknownKey is returned for a step using a key not defined in the keys
+// package.
+var ErrUnknownKey = keys.ErrUnknownKey
+
+// Step is a single step of a macro.
+type Step struct {
+	Action Action `json:"action"`
+	Key    string `json:"key,omitempty"`
+	// Times is the number of clicks of a Click step, once when zero.
+	Times int `json:"times,omitempty"`
+	// Duration is the time waited by a Wait step or the key held down by a
+	// Hold step.
+	Duration Duration `json:"duration,omitempty"`
+	Text     string   `json:"text,omitempty"`
+	App      string   `json:"app,omitempty"`
+	// Delay is the time waited after the step, after every click of a Click
+	// step.
+	Delay Duration `json:"delay,omitempty"`
+}
+
+// Macro is a sequence of steps run in order.
+type Macro struct {
+	Steps []Step `json:"steps"`
+}
+
+// Duration is a time.Duration written as a string such as "1.5s" in JSON, or
+// a number of seconds.
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value interface{}
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case float64:
+		// plain numbers are seconds, as in the text format
+		*d = Duration(time.Duration(v * float64(time.Second)))
+	case string:
+		parsed, err := time.ParseDuration(v)
+
+		if err != nil {
+			return err
+		}
+
+		*d = Duration(parsed)
+	default:
+		return fmt.Errorf("invalid duration %s", data)
+	}
+
+	return nil
+}
+
+// Validate returns an error for the first step which is incomplete or uses a
+// key not defined in the keys package.
+func (m Macro) Validate() error {
+	for i, step := range m.Steps {
+		if err := step.validate(); err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func (s Step) validate() error {
+	switch s.Action {
+	case Click, Press, Release, Hold:
+		if !keys.IsKey(s.Key) {
+			return fmt.Errorf("%w %q", ErrUnknownKey, s.Key)
+		}
+	case Wait:
+		if s.Duration <= 0 {
+			return errors.New("wait requires a duration")
+		}
+	case Text:
+		if s.Text == "" {
+			return errors.New("text requires the text to send")
+		}
+	case App:
+		if s.App == "" {
+			return errors.New("app requires an application name or id")
+		}
+	default:
+		return fmt.Errorf("unknown action %q", s.Action)
+	}
+
+	if s.Times < 0 || s.Duration < 0 || s.Delay < 0 {
+		return errors.New("times, duration and delay cannot be negative")
+	}
+
+	return nil
+}
+
+// Remote is what a macro is run against, such as the websocket of a client
+// launching applications through its catalogue.
+type Remote interface {
+	SendKeyContext(ctx context.Context, key string, times int, cmd string) error
+	SendTextContext(ctx context.Context, text string) error
+	// LaunchApp launches the application with the name or id, resolving the
+	// way it is launched, such as DEEP_LINK or NATIVE_LAUNCH.
+	LaunchApp(ctx context.Context, name string) error
+}
+
+// Run validates the macro and then runs every step against the remote in
+// order, stopping at the first step which fails or once the context is done.
+func (m Macro) Run(ctx context.Context, remote Remote) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	for i, step := range m.Steps {
+		if err := step.run(ctx, remote); err != nil {
+			return fmt.Errorf("step %d (%s): %w", i+1, step, err)
+		}
+	}
+
+	return nil
+}
+
+func (s Step) run(ctx context.Context, remote Remote) error {
+	switch s.Action {
+	case Click:
+		times := s.Times
+
+		if times == 0 {
+			times = 1
+		}
+
+		for i := 0; i < times; i++ {
+			if err := remote.SendKeyContext(ctx, s.Key, 1, "Click"); err != nil {
+				return err
+			}
+
+			if err := sleep(ctx, s.Delay); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case Press:
+		if err := remote.SendKeyContext(ctx, s.Key, 1, "Press"); err != nil {
+			return err
+		}
+	case Release:
+		if err := remote.SendKeyContext(ctx, s.Key, 1, "Release"); err != nil {
+			return err
+		}
+	case Hold:
+		if err := remote.SendKeyContext(ctx, s.Key, 1, "Press"); err != nil {
+			return err
+		}
+
+		holdErr := sleep(ctx, s.Duration)
+
+		// always release the key, even once the context is done
+		if err := remote.SendKeyContext(context.WithoutCancel(ctx), s.Key, 1, "Release"); err != nil {
+			return err
+		}
+
+		if holdErr != nil {
+			return holdErr
+		}
+	case Wait:
+		if err := sleep(ctx, s.Duration); err != nil {
+			return err
+		}
+	case Text:
+		if err := remote.SendTextContext(ctx, base64.StdEncoding.EncodeToString([]byte(s.Text))); err != nil {
+			return err
+		}
+	case App:
+		if err := remote.LaunchApp(ctx, s.App); err != nil {
+			return err
+		}
+	}
+
+	return sleep(ctx, s.Delay)
+}
+
+// String returns the macro in the text format, one step per line.
+func (m Macro) String() string {
+	lines := make([]string, len(m.Steps))
+
+	for i, step := range m.Steps {
+		lines[i] = step.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// String returns the step in the text format.
+func (s Step) String() string {
+	var step string
+
+	switch s.Action {
+	case Click:
+		step = keyName(s.Key)
+
+		if s.Times > 1 {
+			step += fmt.Sprintf(" x%d", s.Times)
+		}
+	case Press, Release:
+		step = fmt.Sprintf("%s %s", s.Action, keyName(s.Key))
+	case Hold:
+		step = fmt.Sprintf("hold %s %s", keyName(s.Key), time.Duration(s.Duration))
+	case Wait:
+		step = fmt.Sprintf("wait %s", time.Duration(s.Duration))
+	case Text:
+		step = fmt.Sprintf("text %q", s.Text)
+	case App:
+		step = fmt.Sprintf("app %s", s.App)
+	default:
+		step = string(s.Action)
+	}
+
+	if s.Delay > 0 {
+		step += fmt.Sprintf(" @%s", time.Duration(s.Delay))
+	}
+
+	return step
+}
+
+// keyName returns the key without the KEY_ prefix, as written in the text
+// format.
+func keyName(key string) string {
+	return strings.TrimPrefix(key, "KEY_")
+}
+
+func sleep(ctx context.Context, d Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(time.Duration(d))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
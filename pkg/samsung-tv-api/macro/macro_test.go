@@ -0,0 +1,122 @@
+package macro
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder is a Remote recording every command sent.
+type recorder struct {
+	commands []string
+}
+
+func (r *recorder) SendKeyContext(ctx context.Context, key string, times int, cmd string) error {
+	r.commands = append(r.commands, fmt.Sprintf("%s %s", cmd, key))
+	return nil
+}
+
+func (r *recorder) SendTextContext(ctx context.Context, text string) error {
+	decoded, _ := base64.StdEncoding.DecodeString(text)
+	r.commands = append(r.commands, fmt.Sprintf("Text %s", decoded))
+	return nil
+}
+
+func (r *recorder) LaunchApp(ctx context.Context, name string) error {
+	r.commands = append(r.commands, fmt.Sprintf("App %s", name))
+	return nil
+}
+
+func TestParse(t *testing.T) {
+	m, err := Parse(`Home, Down x3 @10ms, Right, Enter, wait 2s
+# search
+text "hello, world" @1s
+hold power 1.5
+press KEY_UP; release up
+app 111299001912
+app prime video
+click vol up, volume down x2, hold channel up 2s`)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Step{
+		{Action: Click, Key: "KEY_HOME", Times: 1},
+		{Action: Click, Key: "KEY_DOWN", Times: 3, Delay: Duration(10 * time.Millisecond)},
+		{Action: Click, Key: "KEY_RIGHT", Times: 1},
+		{Action: Click, Key: "KEY_ENTER", Times: 1},
+		{Action: Wait, Duration: Duration(2 * time.Second)},
+		{Action: Text, Text: "hello, world", Delay: Duration(time.Second)},
+		{Action: Hold, Key: "KEY_POWER", Duration: Duration(1500 * time.Millisecond)},
+		{Action: Press, Key: "KEY_UP"},
+		{Action: Release, Key: "KEY_UP"},
+		{Action: App, App: "111299001912"},
+		{Action: App, App: "prime video"},
+		{Action: Click, Key: "KEY_VOLUP", Times: 1},
+		{Action: Click, Key: "KEY_VOLDOWN", Times: 2},
+		{Action: Hold, Key: "KEY_CHUP", Duration: Duration(2 * time.Second)},
+	}, m.Steps)
+}
+
+func TestParseUnknownKey(t *testing.T) {
+	_, err := Parse("Home, Dwon x3")
+	assert.ErrorIs(t, err, ErrUnknownKey)
+	assert.Contains(t, err.Error(), "step 2")
+
+	_, err = Parse("wait soon")
+	assert.Error(t, err)
+}
+
+func TestParseJSON(t *testing.T) {
+	m, err := ParseJSON([]byte(`[
+		{"action": "click", "key": "KEY_DOWN", "times": 3, "delay": "200ms"},
+		{"action": "wait", "duration": 2},
+		{"action": "text", "text": "hi"}
+	]`))
+	assert.NoError(t, err)
+	assert.Equal(t, Step{Action: Click, Key: "KEY_DOWN", Times: 3, Delay: Duration(200 * time.Millisecond)}, m.Steps[0])
+	assert.Equal(t, Duration(2*time.Second), m.Steps[1].Duration)
+
+	_, err = ParseJSON([]byte(`{"steps": [{"action": "click", "key": "KEY_NOPE"}]}`))
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	m, err := Parse(`Home, Down x3 @200ms, hold Power 3s, text "a, \"b\"" @1s, app 111299001912`)
+	assert.NoError(t, err)
+
+	again, err := Parse(m.String())
+	assert.NoError(t, err)
+	assert.Equal(t, m, again)
+}
+
+func TestRun(t *testing.T) {
+	m, err := Parse(`Home, Down x2, text "hi", hold Up 10ms, app 111299001912`)
+	assert.NoError(t, err)
+
+	remote := &recorder{}
+	assert.NoError(t, m.Run(context.Background(), remote))
+	assert.Equal(t, []string{
+		"Click KEY_HOME",
+		"Click KEY_DOWN",
+		"Click KEY_DOWN",
+		"Text hi",
+		"Press KEY_UP",
+		"Release KEY_UP",
+		"App 111299001912",
+	}, remote.commands)
+}
+
+func TestRunCancelled(t *testing.T) {
+	m, err := Parse(`Home, wait 1m, Enter`)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	remote := &recorder{}
+	assert.ErrorIs(t, m.Run(ctx, remote), context.DeadlineExceeded)
+	assert.Equal(t, []string{"Click KEY_HOME"}, remote.commands)
+}
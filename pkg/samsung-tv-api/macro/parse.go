@@ -0,0 +1,320 @@
+package macro
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/keys"
+)
+
+// Load reads the macro in the file, which is parsed as JSON when it has the
+// .json extension or starts with "{" or "[", and as the text format otherwise.
+func Load(path string) (Macro, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return Macro{}, err
+	}
+
+	trimmed := bytes.TrimSpace(content)
+
+	if strings.EqualFold(filepath.Ext(path), ".json") || bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("[")) {
+		return ParseJSON(content)
+	}
+
+	return Parse(string(content))
+}
+
+// ParseJSON parses a macro written as JSON, either an object holding "steps"
+// or the array of steps alone, e.g.
+//
+//	[{"action": "click", "key": "KEY_DOWN", "times": 3, "delay": "200ms"},
+//	 {"action": "wait", "duration": "2s"}]
+func ParseJSON(content []byte) (Macro, error) {
+	var m Macro
+
+	trimmed := bytes.TrimSpace(content)
+
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		if err := json.Unmarshal(trimmed, &m.Steps); err != nil {
+			return m, err
+		}
+	} else if err := json.Unmarshal(trimmed, &m); err != nil {
+		return m, err
+	}
+
+	return m, m.Validate()
+}
+
+// Parse parses a macro written in the text format. Steps are separated by new
+// lines, commas or semicolons and "#" starts a comment:
+//
+//	Home                  click a key, with or without the KEY_ prefix
+//	Down x3               click a key three times
+//	press Up / release Up press or release a key
+//	hold Power 3s         hold a key down for the duration
+//	vol up x2             keys can be written over several words
+//	wait 2s               wait for the duration
+//	text "hello, world"   type the text into the focused input field
+//	app netflix           launch the application by name or id
+//
+// Any step can end with a delay waited after it, e.g. "Down x3 @300ms" waits
+// 300ms after every click.
+func Parse(content string) (Macro, error) {
+	var m Macro
+
+	for i, raw := range splitSteps(content) {
+		step, err := parseStep(raw)
+
+		if err != nil {
+			return m, fmt.Errorf("step %d %q: %w", i+1, raw, err)
+		}
+
+		m.Steps = append(m.Steps, step)
+	}
+
+	return m, m.Validate()
+}
+
+// splitSteps splits the content into steps, ignoring separators within quotes
+// and dropping comments and empty steps.
+func splitSteps(content string) []string {
+	var steps []string
+	var current strings.Builder
+
+	quoted := false
+	comment := false
+
+	flush := func() {
+		if step := strings.TrimSpace(current.String()); step != "" {
+			steps = append(steps, step)
+		}
+
+		current.Reset()
+	}
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+
+		switch {
+		case comment:
+			if c == '\n' {
+				comment = false
+				flush()
+			}
+			continue
+		case quoted:
+			current.WriteByte(c)
+
+			if c == '\\' && i+1 < len(content) {
+				i++
+				current.WriteByte(content[i])
+			} else if c == '"' {
+				quoted = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			quoted = true
+			current.WriteByte(c)
+		case '#':
+			comment = true
+		case '\n', ',', ';':
+			flush()
+		default:
+			current.WriteByte(c)
+		}
+	}
+
+	flush()
+	return steps
+}
+
+// parseStep parses a single step of the text format.
+func parseStep(raw string) (Step, error) {
+	var step Step
+
+	fields := strings.Fields(raw)
+
+	if name := strings.ToLower(fields[0]); name == "text" || name == "type" {
+		return parseText(strings.TrimSpace(raw[len(fields[0]):]))
+	}
+
+	// the delay waited after the step
+	if last := fields[len(fields)-1]; strings.HasPrefix(last, "@") {
+		delay, err := parseDuration(last[1:])
+
+		if err != nil {
+			return step, err
+		}
+
+		step.Delay = Duration(delay)
+		fields = fields[:len(fields)-1]
+
+		if len(fields) == 0 {
+			return step, fmt.Errorf("delay without a step")
+		}
+	}
+
+	args := fields[1:]
+
+	switch strings.ToLower(fields[0]) {
+	case "wait", "sleep":
+		if len(args) != 1 {
+			return step, fmt.Errorf("expected wait <duration>")
+		}
+
+		duration, err := parseDuration(args[0])
+		step.Action = Wait
+		step.Duration = Duration(duration)
+
+		return step, err
+	case "press", "release":
+		if len(args) < 1 {
+			return step, fmt.Errorf("expected %s <key>", fields[0])
+		}
+
+		step.Action = Action(strings.ToLower(fields[0]))
+		step.Key = NormalizeKey(strings.Join(args, " "))
+
+		return step, nil
+	case "hold":
+		if len(args) < 1 {
+			return step, fmt.Errorf("expected hold <key> [duration]")
+		}
+
+		step.Action = Hold
+		step.Duration = Duration(time.Second)
+
+		// the duration is optional, so the last word is only taken as one
+		// when it parses as a duration.
+		if len(args) > 1 {
+			if duration, err := parseDuration(args[len(args)-1]); err == nil {
+				step.Duration = Duration(duration)
+				args = args[:len(args)-1]
+			}
+		}
+
+		step.Key = NormalizeKey(strings.Join(args, " "))
+
+		return step, nil
+	case "app", "launch":
+		if len(args) < 1 {
+			return step, fmt.Errorf("expected app <name or id>")
+		}
+
+		step.Action = App
+		step.App = strings.Join(args, " ")
+
+		return step, nil
+	case "click":
+		fields = args
+	}
+
+	if len(fields) < 1 {
+		return step, fmt.Errorf("expected <key> [xN]")
+	}
+
+	step.Action = Click
+	step.Times = 1
+
+	// the repeat is optional, so the last word is only taken as one when it
+	// is a number, e.g. x3.
+	if len(fields) > 1 {
+		last := fields[len(fields)-1]
+
+		if times, err := strconv.Atoi(strings.TrimLeft(strings.ToLower(last), "x*")); err == nil {
+			if times < 1 {
+				return step, fmt.Errorf("invalid repeat %q, expected e.g. x3", last)
+			}
+
+			step.Times = times
+			fields = fields[:len(fields)-1]
+		}
+	}
+
+	step.Key = NormalizeKey(strings.Join(fields, " "))
+
+	return step, nil
+}
+
+// parseText parses the argument of a text step, which is either the rest of
+// the step or a quoted string optionally followed by a delay.
+func parseText(arg string) (Step, error) {
+	step := Step{Action: Text, Text: arg}
+
+	if !strings.HasPrefix(arg, "\"") {
+		return step, nil
+	}
+
+	quoted, err := strconv.QuotedPrefix(arg)
+
+	if err != nil {
+		return step, fmt.Errorf("invalid quoted text: %w", err)
+	}
+
+	step.Text, _ = strconv.Unquote(quoted)
+
+	rest := strings.TrimSpace(arg[len(quoted):])
+
+	if rest == "" {
+		return step, nil
+	}
+
+	if !strings.HasPrefix(rest, "@") {
+		return step, fmt.Errorf("unexpected %q after text", rest)
+	}
+
+	delay, err := parseDuration(rest[1:])
+	step.Delay = Duration(delay)
+
+	return step, err
+}
+
+// NormalizeKey converts a key as written in a macro, such as "home", "vol up"
+// or "KEY_HOME", into the name of the key sent to the TV. Keys are matched with
+// keys.Parse, keys it does not know being upper cased with the KEY_ prefix.
+func NormalizeKey(key string) string {
+	if k, err := keys.Parse(key); err == nil {
+		return k.Code
+	}
+
+	key = strings.Map(func(r rune) rune {
+		if r == '-' || unicode.IsSpace(r) {
+			return '_'
+		}
+
+		return unicode.ToUpper(r)
+	}, key)
+
+	if !strings.HasPrefix(key, "KEY_") {
+		key = "KEY_" + key
+	}
+
+	return key
+}
+
+// parseDuration parses a duration such as "2s" or "500ms", plain numbers being
+// seconds.
+func parseDuration(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	duration, err := time.ParseDuration(value)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	return duration, nil
+}
@@ -0,0 +1,85 @@
+package samsung_tv_api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/apps"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/macro"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/samsungtvtest"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunMacro(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+	client.Websocket.KeyPressDelay = 0
+
+	assert.NoError(t, client.ConnectionSetup())
+	defer client.Disconnect()
+
+	m, err := macro.Parse(`Home, Down x2, text "hello"`)
+	assert.NoError(t, err)
+	assert.NoError(t, client.RunMacro(context.Background(), m))
+
+	assert.Eventually(t, func() bool { return len(tv.Texts()) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []samsungtvtest.KeyPress{
+		{Cmd: "Click", Key: "KEY_HOME"},
+		{Cmd: "Click", Key: "KEY_DOWN"},
+		{Cmd: "Click", Key: "KEY_DOWN"},
+	}, tv.Keys())
+	assert.Equal(t, []string{"hello"}, tv.Texts())
+}
+
+func TestRunMacroLaunchesApps(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+	client.TokenStore = store.NewMemoryStore()
+	tv.AddApp(samsungtvtest.App{ID: apps.NetflixID, Name: "Netflix", AppType: apps.TypeWebApp})
+	tv.AddApp(samsungtvtest.App{ID: apps.BrowserID, Name: "Browser", AppType: apps.TypeNative})
+
+	assert.NoError(t, client.ConnectionSetup())
+	defer client.Disconnect()
+
+	m, err := macro.Parse(`app netflix, app browser`)
+	assert.NoError(t, err)
+	assert.NoError(t, client.RunMacro(context.Background(), m))
+
+	// applications are launched with the action type of the catalogue rather
+	// than always as a deep link.
+	assert.Equal(t, []samsungtvtest.Launch{
+		{AppID: apps.NetflixID, ActionType: "DEEP_LINK"},
+		{AppID: apps.BrowserID, ActionType: "NATIVE_LAUNCH"},
+	}, tv.Launches())
+}
+
+func TestNamedMacros(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+	client.Websocket.KeyPressDelay = 0
+
+	m, err := macro.Parse(`Home, Down x2`)
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.SaveMacro("netflix-profile-2", m))
+	assert.NoError(t, client.SaveMacro("home", macro.Macro{Steps: m.Steps[:1]}))
+	assert.Equal(t, []string{"home", "netflix-profile-2"}, client.Macros())
+
+	saved, err := client.Macro("netflix-profile-2")
+	assert.NoError(t, err)
+	assert.Equal(t, m, saved)
+
+	// the macros are kept with the device configuration
+	raw, err := json.Marshal(client.cfg)
+	assert.NoError(t, err)
+	assert.Contains(t, string(raw), `"macros":{"home":[{"action":"click","key":"KEY_HOME","times":1}]`)
+
+	assert.NoError(t, client.ConnectionSetup())
+	defer client.Disconnect()
+
+	assert.NoError(t, client.RunNamedMacro(context.Background(), "home"))
+	assert.Eventually(t, func() bool { return len(tv.Keys()) == 1 }, time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, client.DeleteMacro("home"))
+	assert.ErrorIs(t, client.RunNamedMacro(context.Background(), "home"), ErrMacroNotFound)
+}
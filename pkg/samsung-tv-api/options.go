@@ -0,0 +1,69 @@
+package samsung_tv_api
+
+import (
+	"crypto/tls"
+	"net/http"
+)
+
+// Option configures a SamsungTvClient created through New.
+type Option func(*SamsungTvClient)
+
+// WithPort sets the port of the rest api and websocket channels, disabling
+// probing. Port LegacyPort is served over plain http and ws, every other port
+// over TLS.
+func WithPort(port int) Option {
+	return func(s *SamsungTvClient) {
+		s.port = port
+	}
+}
+
+// WithUpnpPort sets the port of the upnp services, DefaultUpnpPort by default.
+func WithUpnpPort(port int) Option {
+	return func(s *SamsungTvClient) {
+		s.upnpPort = port
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to the rest api and
+// websocket channels, replacing the pinning of the certificate of the TV.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(s *SamsungTvClient) {
+		s.tlsConfig = config
+	}
+}
+
+// WithName sets the name the client is shown as on the TV, for example when
+// asked to allow the connection.
+func WithName(name string) Option {
+	return func(s *SamsungTvClient) {
+		s.name = name
+	}
+}
+
+// WithHTTPClient sets the client used for requests to the rest api and upnp
+// services, taking precedence over WithTLSConfig for the rest api. Unless
+// WithTLSConfig is provided, connections to the rest api are still rejected
+// when the certificate of the TV does not match the pinned certificate. The
+// check is made during the handshake when the transport of the client is an
+// *http.Transport, any other transport has its responses rejected after the
+// request was sent.
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *SamsungTvClient) {
+		s.httpClient = client
+	}
+}
+
+// WithKeyPressDelay sets the delay in milliseconds between repeated key presses.
+func WithKeyPressDelay(delay int) Option {
+	return func(s *SamsungTvClient) {
+		s.keyPressDelay = delay
+	}
+}
+
+// WithUnknownKeys sends keys missing from the keys registry instead of
+// rejecting them, for models accepting keys which are not listed.
+func WithUnknownKeys() Option {
+	return func(s *SamsungTvClient) {
+		s.unknownKeys = true
+	}
+}
@@ -0,0 +1,125 @@
+package samsung_tv_api
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/store"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+)
+
+// PairingState describes the progress of pairing with the TV.
+type PairingState string
+
+const (
+	// PairingAwaitingApproval is reported once the connection has been
+	// requested and the TV is showing the prompt to allow it.
+	PairingAwaitingApproval PairingState = "awaiting_approval"
+	PairingApproved         PairingState = "approved"
+	PairingDenied           PairingState = "denied"
+	PairingTimedOut         PairingState = "timed_out"
+)
+
+// Pair opens the remote control channel and waits for the user to allow the
+// connection on the TV, returning the issued token. Progress is reported to
+// OnPairingState when set and the token is saved to the TokenStore when set.
+//
+// A denied connection returns an error matching tverrors.ErrUnauthorized, while
+// the user not responding in time, either reported by the TV or through the
+// context deadline, returns an error matching tverrors.ErrPairingTimeout.
+func (s *SamsungTvClient) Pair(ctx context.Context) (string, error) {
+	s.resolvePort(ctx)
+	s.reportPairingState(PairingAwaitingApproval)
+
+	wsResp, err := s.Websocket.OpenConnectionContext(ctx)
+
+	switch {
+	case errors.Is(err, tverrors.ErrUnauthorized):
+		s.reportPairingState(PairingDenied)
+		return "", err
+	case errors.Is(err, tverrors.ErrPairingTimeout):
+		s.reportPairingState(PairingTimedOut)
+		return "", err
+	case errors.Is(err, context.DeadlineExceeded):
+		s.reportPairingState(PairingTimedOut)
+		return "", errors.Join(tverrors.ErrPairingTimeout, err)
+	case err != nil:
+		return "", err
+	}
+
+	token := wsResp.Data.Token
+	if token == "" {
+		// the TV only issues a token when one is needed, the current token
+		// is still valid when none is sent.
+		token = s.cfg.Token
+	}
+
+	s.cfg.Token = token
+	s.reportPairingState(PairingApproved)
+
+	if err := s.identify(ctx); err != nil {
+		log.Printf("unable to identify device, storing token by ip: %v", err)
+	}
+
+	if err := s.saveToken(); err != nil {
+		return token, err
+	}
+
+	return token, nil
+}
+
+func (s *SamsungTvClient) reportPairingState(state PairingState) {
+	log.Printf("pairing state %s\n", state)
+
+	if s.OnPairingState != nil {
+		s.OnPairingState(state)
+	}
+}
+
+// LoadToken loads the token of the TV from the TokenStore, keeping the current
+// token when the store holds none.
+func (s *SamsungTvClient) LoadToken() error {
+	if s.TokenStore == nil {
+		return nil
+	}
+
+	token, err := s.TokenStore.Token(s.cfg.Key())
+
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	s.cfg.Token = token
+	return nil
+}
+
+// identify fills in the device id from the rest api when not yet known, so
+// tokens are stored against the device rather than its ip address.
+func (s *SamsungTvClient) identify(ctx context.Context) error {
+	if s.cfg.ID != "" {
+		return nil
+	}
+
+	deviceInfo, err := s.Rest.GetDeviceInfoContext(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	s.cfg.ID = deviceInfo.Device.Duid
+	return nil
+}
+
+// saveToken saves the current token to the TokenStore, if any.
+func (s *SamsungTvClient) saveToken() error {
+	if s.TokenStore == nil || s.cfg.Token == "" {
+		return nil
+	}
+
+	return s.TokenStore.SaveToken(s.cfg.Key(), s.cfg.Token)
+}
@@ -0,0 +1,70 @@
+package samsung_tv_api
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/store"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
+)
+
+// getPairingClient returns a client connected to a TV which answers the
+// connection request with the given frame.
+func getPairingClient(t *testing.T, frame string) (*SamsungTvClient, *[]PairingState) {
+	srv := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		_ = websocket.Message.Send(ws, frame)
+		var msg string
+		_ = websocket.Message.Receive(ws, &msg)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := getTestClient()
+	client.Websocket.BaseUrl = func(endpoint string) *url.URL {
+		return &url.URL{Scheme: "ws", Host: strings.TrimPrefix(srv.URL, "http://"), Path: endpoint}
+	}
+
+	var states []PairingState
+	client.OnPairingState = func(state PairingState) {
+		states = append(states, state)
+	}
+
+	return client, &states
+}
+
+func TestPairApproved(t *testing.T) {
+	client, states := getPairingClient(t, `{"event":"ms.channel.connect","data":{"clients":[{"id":"1"}],"token":"12345678"}}`)
+	tokens := store.NewMemoryStore()
+	client.TokenStore = tokens
+	client.cfg.Ip = "10.0.0.2"
+
+	token, err := client.Pair(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "12345678", token)
+	assert.Equal(t, []PairingState{PairingAwaitingApproval, PairingApproved}, *states)
+
+	stored, err := tokens.Token("10.0.0.2")
+	assert.NoError(t, err)
+	assert.Equal(t, "12345678", stored)
+	assert.NoError(t, client.Disconnect())
+}
+
+func TestPairDenied(t *testing.T) {
+	client, states := getPairingClient(t, `{"event":"ms.channel.unauthorized"}`)
+
+	_, err := client.Pair(context.Background())
+	assert.ErrorIs(t, err, tverrors.ErrUnauthorized)
+	assert.Equal(t, []PairingState{PairingAwaitingApproval, PairingDenied}, *states)
+}
+
+func TestPairTimedOut(t *testing.T) {
+	client, states := getPairingClient(t, `{"event":"ms.channel.timeOut"}`)
+
+	_, err := client.Pair(context.Background())
+	assert.ErrorIs(t, err, tverrors.ErrPairingTimeout)
+	assert.Equal(t, []PairingState{PairingAwaitingApproval, PairingTimedOut}, *states)
+}
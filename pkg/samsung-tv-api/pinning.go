@@ -0,0 +1,161 @@
+package samsung_tv_api
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+)
+
+// Fingerprint returns the SHA-256 fingerprint of the certificate as hex, being
+// the form certificates are pinned in.
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// pinnedTLSConfig returns the TLS configuration used when none is provided
+// through WithTLSConfig. The TV presents a self-signed certificate, so rather
+// than verifying the chain the certificate is trusted on first use: once the
+// device has a CertFingerprint any other certificate is rejected with a
+// *tverrors.CertificateError.
+func (s *SamsungTvClient) pinnedTLSConfig() *tls.Config {
+	return &tls.Config{
+		InsecureSkipVerify: true,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 {
+				return errors.New("tv presented no certificate")
+			}
+
+			actual := Fingerprint(state.PeerCertificates[0])
+
+			s.pinMutex.Lock()
+			s.seenFingerprint = actual
+			expected := s.cfg.CertFingerprint
+			s.pinMutex.Unlock()
+
+			if expected != "" && expected != actual {
+				return &tverrors.CertificateError{Expected: expected, Actual: actual}
+			}
+
+			return nil
+		},
+	}
+}
+
+// pinnedTransport checks the certificate of every TLS response of the wrapped
+// transport. The certificate is only checked once the response is received, so
+// the request has already been sent to the peer, see pinnedHTTPClient.
+type pinnedTransport struct {
+	base   http.RoundTripper
+	verify func(tls.ConnectionState) error
+}
+
+func (t *pinnedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+
+	if err != nil || resp.TLS == nil {
+		return resp, err
+	}
+
+	if err := t.verify(*resp.TLS); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// pinnedHTTPClient returns a copy of the client checking the certificate of
+// the TV like pinnedTLSConfig does. When the transport of the client is an
+// *http.Transport the check is made during the TLS handshake of a copy of it,
+// before any request is sent. Any other transport is wrapped in a
+// pinnedTransport, which can only reject the response.
+func (s *SamsungTvClient) pinnedHTTPClient(client *http.Client) *http.Client {
+	base := client.Transport
+
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	pinned := *client
+
+	if transport, ok := base.(*http.Transport); ok {
+		transport = transport.Clone()
+
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+
+		transport.TLSClientConfig.InsecureSkipVerify = true
+		transport.TLSClientConfig.VerifyConnection = s.tlsConfig.VerifyConnection
+
+		pinned.Transport = transport
+		return &pinned
+	}
+
+	pinned.Transport = &pinnedTransport{base: base, verify: s.tlsConfig.VerifyConnection}
+	return &pinned
+}
+
+// pinCertificate trusts the certificate presented on the last connection when
+// the device has no trusted certificate yet.
+func (s *SamsungTvClient) pinCertificate() {
+	s.pinMutex.Lock()
+	defer s.pinMutex.Unlock()
+
+	if s.cfg.CertFingerprint == "" && s.seenFingerprint != "" {
+		s.cfg.CertFingerprint = s.seenFingerprint
+	}
+}
+
+// FetchFingerprint connects to the TV and returns the fingerprint of the
+// certificate it presents, without checking it against the trusted certificate.
+func (s *SamsungTvClient) FetchFingerprint(ctx context.Context) (string, error) {
+	s.resolvePort(ctx)
+
+	if !s.isSslConnection() {
+		return "", errors.New("tv is not serving its api over TLS")
+	}
+
+	dialer := tls.Dialer{Config: &tls.Config{InsecureSkipVerify: true}}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.cfg.Ip, strconv.Itoa(s.Port())))
+
+	if err != nil {
+		return "", err
+	}
+
+	defer conn.Close()
+
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+
+	if len(certs) == 0 {
+		return "", errors.New("tv presented no certificate")
+	}
+
+	return Fingerprint(certs[0]), nil
+}
+
+// Trust replaces the trusted certificate of the device with the certificate the
+// TV currently presents, returning its fingerprint. This is needed after the
+// TV was reset and issued a new certificate.
+func (s *SamsungTvClient) Trust(ctx context.Context) (string, error) {
+	fingerprint, err := s.FetchFingerprint(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	s.pinMutex.Lock()
+	defer s.pinMutex.Unlock()
+
+	s.cfg.CertFingerprint = fingerprint
+	return fingerprint, nil
+}
@@ -0,0 +1,113 @@
+package samsung_tv_api
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/device"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertificatePinnedOnFirstConnection(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+
+	assert.NoError(t, client.ConnectionSetup())
+	defer client.Disconnect()
+
+	assert.Equal(t, Fingerprint(tv.TV.Certificate()), client.cfg.CertFingerprint)
+}
+
+func TestCertificateMismatch(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+	client.cfg.CertFingerprint = "0000"
+
+	err := client.ConnectionSetup()
+	assert.ErrorIs(t, err, tverrors.ErrCertificateMismatch)
+
+	var certErr *tverrors.CertificateError
+	assert.ErrorAs(t, err, &certErr)
+	assert.Equal(t, "0000", certErr.Expected)
+	assert.Equal(t, Fingerprint(tv.TV.Certificate()), certErr.Actual)
+
+	_, err = client.Rest.GetDeviceInfo()
+	assert.ErrorIs(t, err, tverrors.ErrCertificateMismatch)
+
+	fingerprint, err := client.Trust(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Fingerprint(tv.TV.Certificate()), fingerprint)
+
+	assert.NoError(t, client.ConnectionSetup())
+	assert.NoError(t, client.Disconnect())
+}
+
+func TestArtUploadCertificateMismatch(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+	defer client.Art.Disconnect()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "art.jpg")
+	assert.NoError(t, os.WriteFile(path, []byte("jpeg"), 0o600))
+
+	// the art channel is connected first, so only the upload socket is
+	// checked against the other certificate.
+	_, err := client.Art.GetArtMode(ctx)
+	assert.NoError(t, err)
+
+	client.cfg.CertFingerprint = "0000"
+
+	_, err = client.Art.Upload(ctx, path, "")
+	assert.ErrorIs(t, err, tverrors.ErrCertificateMismatch)
+	assert.Empty(t, tv.Artwork())
+
+	client.cfg.CertFingerprint = Fingerprint(tv.TV.Certificate())
+
+	_, err = client.Art.Upload(ctx, path, "")
+	assert.NoError(t, err)
+	assert.Len(t, tv.Artwork(), 1)
+}
+
+func TestHTTPClientCertificateMismatch(t *testing.T) {
+	_, tv := getFakeTVClient(t)
+
+	insecure := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+		Timeout:   time.Second,
+	}
+
+	cfg := &device.DeviceInfo{Type: "samsungtv", Ip: tv.Host(), CertFingerprint: "0000"}
+	client := New(cfg, WithPort(tv.Port()), WithHTTPClient(insecure))
+
+	_, err := client.Rest.GetDeviceInfo()
+	assert.ErrorIs(t, err, tverrors.ErrCertificateMismatch)
+
+	cfg.CertFingerprint = Fingerprint(tv.TV.Certificate())
+
+	_, err = client.Rest.GetDeviceInfo()
+	assert.NoError(t, err)
+
+	// transports other than *http.Transport are checked on the response.
+	wrapped := &http.Client{
+		Transport: roundTripperFunc(insecure.Transport.RoundTrip),
+		Timeout:   time.Second,
+	}
+
+	cfg.CertFingerprint = "0000"
+	client = New(cfg, WithPort(tv.Port()), WithHTTPClient(wrapped))
+
+	_, err = client.Rest.GetDeviceInfo()
+	assert.ErrorIs(t, err, tverrors.ErrCertificateMismatch)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,213 @@
+package samsung_tv_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/websocket"
+)
+
+// PowerState describes whether the TV is turned on.
+type PowerState string
+
+const (
+	PowerStateOn PowerState = "on"
+	// PowerStateStandby is reported when the TV answers the rest api while
+	// the screen is turned off.
+	PowerStateStandby PowerState = "standby"
+	// PowerStateOff is reported when the TV does not answer at all, it can
+	// only be turned on through wake on lan.
+	PowerStateOff PowerState = "off"
+)
+
+// PowerPolicy configures how SetPower changes the power state. Zero values are
+// replaced with the defaults below.
+type PowerPolicy struct {
+	// Timeout bounds SetPower when the context has no deadline. Defaults to
+	// 30s.
+	Timeout time.Duration
+	// Attempts is the number of times the power key or wake on lan packet is
+	// sent before giving up. Defaults to 3.
+	Attempts int
+	// RetryInterval is the time waited for the power state to change before
+	// sending again. Defaults to 8s.
+	RetryInterval time.Duration
+	// PollInterval is the time between reading the power state. Defaults to
+	// 500ms.
+	PollInterval time.Duration
+}
+
+func (p PowerPolicy) withDefaults() PowerPolicy {
+	if p.Timeout <= 0 {
+		p.Timeout = 30 * time.Second
+	}
+
+	if p.Attempts <= 0 {
+		p.Attempts = 3
+	}
+
+	if p.RetryInterval <= 0 {
+		p.RetryInterval = 8 * time.Second
+	}
+
+	if p.PollInterval <= 0 {
+		p.PollInterval = 500 * time.Millisecond
+	}
+
+	return p
+}
+
+// PowerState returns the power state reported by the TV through the rest api
+// on newer models. Older models, which do not report it, are regarded as on
+// when the upnp services also respond and in standby otherwise.
+//
+// A TV not answering the rest api is regarded as off, only errors of the
+// context or a certificate mismatch are returned.
+func (s *SamsungTvClient) PowerState(ctx context.Context) (PowerState, error) {
+	s.resolvePort(ctx)
+
+	deviceInfo, err := s.Rest.GetDeviceInfoContext(ctx)
+
+	switch {
+	case errors.Is(err, tverrors.ErrCertificateMismatch):
+		return "", err
+	case ctx.Err() != nil:
+		return "", ctx.Err()
+	case err != nil:
+		return PowerStateOff, nil
+	}
+
+	switch deviceInfo.Device.PowerState {
+	case "on":
+		return PowerStateOn, nil
+	case "standby":
+		return PowerStateStandby, nil
+	}
+
+	// turned off TV stops answering upnp requests
+	if _, err := s.Upnp.GetCurrentVolumeContext(ctx); err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		return PowerStateStandby, nil
+	}
+
+	return PowerStateOn, nil
+}
+
+// SetPower turns the TV on or off, doing nothing if it already is. The TV is
+// turned on through the power key when in standby and wake on lan when off,
+// both of which are sent again until the power state changes, as configured
+// by the PowerPolicy of the client.
+//
+// tverrors.ErrPowerTimeout is returned if the power state did not change.
+func (s *SamsungTvClient) SetPower(ctx context.Context, on bool) error {
+	policy := s.PowerPolicy.withDefaults()
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		defer cancel()
+	}
+
+	for attempt := 0; attempt < policy.Attempts; attempt++ {
+		state, err := s.PowerState(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if (state == PowerStateOn) == on {
+			return nil
+		}
+
+		if err := s.sendPower(ctx, state); err != nil {
+			log.Printf("unable to change power state from %s: %v\n", state, err)
+		}
+
+		done, err := s.waitForPower(ctx, on, policy)
+
+		if err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w after %d attempts", tverrors.ErrPowerTimeout, policy.Attempts)
+}
+
+// sendPower sends what changes the power state of the TV from the given
+// state, wake on lan when off and the power key otherwise.
+func (s *SamsungTvClient) sendPower(ctx context.Context, state PowerState) error {
+	if state == PowerStateOff {
+		return s.Wake()
+	}
+
+	if s.Websocket.State() != websocket.StateConnected {
+		if err := s.ConnectionSetupContext(ctx); err != nil {
+			return err
+		}
+	}
+
+	return s.Websocket.SendClickContext(ctx, "KEY_POWER")
+}
+
+// waitForPower polls the power state until it matches or the retry interval
+// passes, returning true if it matched.
+func (s *SamsungTvClient) waitForPower(ctx context.Context, on bool, policy PowerPolicy) (bool, error) {
+	retry := time.NewTimer(policy.RetryInterval)
+	defer retry.Stop()
+
+	ticker := time.NewTicker(policy.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false, errors.Join(tverrors.ErrPowerTimeout, ctx.Err())
+		case <-retry.C:
+			return false, nil
+		case <-ticker.C:
+		}
+
+		state, err := s.PowerState(ctx)
+
+		if ctx.Err() != nil {
+			return false, errors.Join(tverrors.ErrPowerTimeout, ctx.Err())
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		if (state == PowerStateOn) == on {
+			return true, nil
+		}
+	}
+}
+
+func (s *SamsungTvClient) PowerOn() error {
+	return s.PowerOnContext(context.Background())
+}
+
+// PowerOnContext is PowerOn bound to the provided context, see SetPower.
+func (s *SamsungTvClient) PowerOnContext(ctx context.Context) error {
+	return s.SetPower(ctx, true)
+}
+
+func (s *SamsungTvClient) PowerOff() error {
+	return s.PowerOffContext(context.Background())
+}
+
+// PowerOffContext is PowerOff bound to the provided context, see SetPower.
+func (s *SamsungTvClient) PowerOffContext(ctx context.Context) error {
+	return s.SetPower(ctx, false)
+}
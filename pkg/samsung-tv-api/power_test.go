@@ -0,0 +1,74 @@
+package samsung_tv_api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/samsungtvtest"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPowerState(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+
+	state, err := client.PowerState(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, PowerStateOn, state)
+
+	tv.SetDevice("PowerState", "standby")
+
+	state, err = client.PowerState(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, PowerStateStandby, state)
+
+	// older models only report being on through the upnp services
+	tv.SetDevice("PowerState", "")
+
+	state, err = client.PowerState(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, PowerStateOn, state)
+
+	tv.Close()
+
+	state, err = client.PowerState(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, PowerStateOff, state)
+}
+
+func TestSetPowerIdempotent(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+	client.PowerPolicy = PowerPolicy{PollInterval: 10 * time.Millisecond}
+	defer client.Disconnect()
+
+	assert.NoError(t, client.SetPower(context.Background(), false))
+	assert.NoError(t, client.SetPower(context.Background(), false))
+	assert.Equal(t, []samsungtvtest.KeyPress{{Cmd: "Click", Key: "KEY_POWER"}}, tv.Keys())
+
+	assert.NoError(t, client.SetPower(context.Background(), true))
+	assert.NoError(t, client.SetPower(context.Background(), true))
+	assert.Len(t, tv.Keys(), 2)
+
+	state, err := client.PowerState(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, PowerStateOn, state)
+}
+
+func TestSetPowerRetries(t *testing.T) {
+	client, tv := getFakeTVClient(t)
+	client.PowerPolicy = PowerPolicy{
+		Attempts:      2,
+		RetryInterval: 50 * time.Millisecond,
+		PollInterval:  10 * time.Millisecond,
+	}
+	defer client.Disconnect()
+
+	// an older model in standby which ignores the power key
+	tv.SetDevice("PowerState", "")
+	tv.Upnp.Close()
+
+	err := client.SetPower(context.Background(), true)
+	assert.ErrorIs(t, err, tverrors.ErrPowerTimeout)
+	assert.Len(t, tv.Keys(), 2)
+}
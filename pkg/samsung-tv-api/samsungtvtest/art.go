@@ -0,0 +1,233 @@
+package samsungtvtest
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// artChannel is the websocket channel of the art app of Frame TVs.
+const artChannel = "com.samsung.art-app"
+
+// Artwork is a piece of artwork stored by the art app of the fake TV.
+type Artwork struct {
+	ContentID string
+	MatteID   string
+	FilterID  string
+	FileType  string
+	// Data is the uploaded image.
+	Data []byte
+}
+
+// artState is the state of the art app.
+type artState struct {
+	mode     string
+	selected string
+	artwork  []*Artwork
+}
+
+// uploadHeader is the header sent ahead of an image over the upload socket.
+type uploadHeader struct {
+	FileLength int    `json:"fileLength"`
+	FileType   string `json:"fileType"`
+	SecKey     string `json:"secKey"`
+}
+
+// isArtChannel returns true when the connection was opened on the art channel.
+func isArtChannel(conn *websocket.Conn) bool {
+	return strings.HasSuffix(conn.Request().URL.Path, artChannel)
+}
+
+// handleArtRequest answers a request sent to the art app, which is a JSON
+// encoded string within the data of the event, with a d2d_service_message
+// carrying the request id.
+func (s *Server) handleArtRequest(conn *websocket.Conn, lock *sync.Mutex, req request) {
+	var data string
+	var art map[string]interface{}
+
+	if err := json.Unmarshal(req.Params.Data, &data); err != nil {
+		return
+	}
+
+	if err := json.Unmarshal([]byte(data), &art); err != nil {
+		return
+	}
+
+	id, _ := art["request_id"].(string)
+	contentID, _ := art["content_id"].(string)
+
+	respond := func(response map[string]interface{}) {
+		response["request_id"] = id
+		response["id"] = id
+		payload, _ := json.Marshal(response)
+		send(conn, lock, "d2d_service_message", string(payload))
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	artwork := s.artwork(contentID)
+
+	switch art["request"] {
+	case "get_artmode_status":
+		respond(map[string]interface{}{"event": "artmode_status", "value": s.art.mode})
+	case "set_artmode_status":
+		s.art.mode, _ = art["value"].(string)
+		respond(map[string]interface{}{"event": "artmode_status", "value": s.art.mode})
+	case "get_content_list":
+		list := make([]map[string]interface{}, 0, len(s.art.artwork))
+		for _, item := range s.art.artwork {
+			list = append(list, map[string]interface{}{
+				"content_id":  item.ContentID,
+				"category_id": "MY-C0002",
+				"matte_id":    item.MatteID,
+				"file_type":   item.FileType,
+			})
+		}
+		raw, _ := json.Marshal(list)
+		respond(map[string]interface{}{"event": "get_content_list", "content_list": string(raw)})
+	case "select_image":
+		if artwork == nil {
+			respond(map[string]interface{}{"event": "error", "error_code": "-1"})
+			return
+		}
+		s.art.selected = contentID
+		respond(map[string]interface{}{"event": "image_selected", "content_id": contentID})
+	case "change_matte":
+		if artwork == nil {
+			respond(map[string]interface{}{"event": "error", "error_code": "-1"})
+			return
+		}
+		artwork.MatteID, _ = art["matte_id"].(string)
+		respond(map[string]interface{}{"event": "matte_changed", "content_id": contentID})
+	case "set_photo_filter":
+		if artwork == nil {
+			respond(map[string]interface{}{"event": "error", "error_code": "-1"})
+			return
+		}
+		artwork.FilterID, _ = art["filter_id"].(string)
+		respond(map[string]interface{}{"event": "filter_changed", "content_id": contentID})
+	case "send_image":
+		info, err := s.receiveImage(conn, lock, art)
+
+		if err != nil {
+			respond(map[string]interface{}{"event": "error", "error_code": "-1"})
+			return
+		}
+
+		respond(map[string]interface{}{"event": "ready_to_use", "conn_info": info})
+	default:
+		respond(map[string]interface{}{"event": "error", "error_code": "-1"})
+	}
+}
+
+// artwork returns the artwork with the content id, if any. Callers must hold
+// the mutex.
+func (s *Server) artwork(contentID string) *Artwork {
+	for _, item := range s.art.artwork {
+		if item.ContentID == contentID {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// receiveImage opens the socket an image is uploaded over, returning the
+// connection info sent to the client. The socket is served over TLS with the
+// certificate of the TV, and image_added is sent once the image is received.
+func (s *Server) receiveImage(conn *websocket.Conn, lock *sync.Mutex, art map[string]interface{}) (string, error) {
+	listener, err := net.ListenTCP("tcp", &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)})
+
+	if err != nil {
+		return "", err
+	}
+
+	key := newID()
+	matte, _ := art["matte_id"].(string)
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	go func() {
+		defer listener.Close()
+
+		artwork, err := readImage(listener, &tls.Config{Certificates: s.TV.TLS.Certificates}, key)
+
+		if err != nil {
+			return
+		}
+
+		s.mutex.Lock()
+		artwork.ContentID = fmt.Sprintf("MY_F%04d", len(s.art.artwork)+1)
+		artwork.MatteID = matte
+		s.art.artwork = append(s.art.artwork, artwork)
+		s.mutex.Unlock()
+
+		payload, _ := json.Marshal(map[string]interface{}{
+			"event":      "image_added",
+			"request_id": newID(),
+			"content_id": artwork.ContentID,
+		})
+		send(conn, lock, "d2d_service_message", string(payload))
+	}()
+
+	info, err := json.Marshal(map[string]interface{}{
+		"ip":      "127.0.0.1",
+		"port":    fmt.Sprint(port),
+		"key":     key,
+		"secured": true,
+	})
+
+	return string(info), err
+}
+
+// readImage accepts a single upload, which is the length of a JSON header
+// followed by the header and the image.
+func readImage(listener *net.TCPListener, config *tls.Config, key string) (*Artwork, error) {
+	_ = listener.SetDeadline(time.Now().Add(5 * time.Second))
+
+	raw, err := listener.Accept()
+
+	if err != nil {
+		return nil, err
+	}
+
+	conn := tls.Server(raw, config)
+
+	defer conn.Close()
+
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	size := make([]byte, 4)
+	if _, err := io.ReadFull(conn, size); err != nil {
+		return nil, err
+	}
+
+	encoded := make([]byte, binary.BigEndian.Uint32(size))
+	if _, err := io.ReadFull(conn, encoded); err != nil {
+		return nil, err
+	}
+
+	var header uploadHeader
+	if err := json.Unmarshal(encoded, &header); err != nil {
+		return nil, err
+	}
+
+	if header.SecKey != key {
+		return nil, fmt.Errorf("upload sent with key %q, expected %q", header.SecKey, key)
+	}
+
+	data := make([]byte, header.FileLength)
+	if _, err := io.ReadFull(conn, data); err != nil {
+		return nil, err
+	}
+
+	return &Artwork{FileType: header.FileType, Data: data}, nil
+}
@@ -0,0 +1,237 @@
+package samsungtvtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sync"
+
+	"golang.org/x/net/websocket"
+)
+
+// request is a frame sent by a client over a websocket channel.
+type request struct {
+	Method string `json:"method"`
+	Params struct {
+		Cmd          string      `json:"Cmd"`
+		DataOfCmd    string      `json:"DataOfCmd"`
+		Option       interface{} `json:"Option"`
+		TypeOfRemote string      `json:"TypeOfRemote"`
+		Position     struct {
+			X int `json:"x"`
+			Y int `json:"y"`
+		} `json:"Position"`
+		Event string          `json:"event"`
+		To    string          `json:"to"`
+		Data  json.RawMessage `json:"data"`
+	} `json:"params"`
+}
+
+type launchData struct {
+	ActionType string `json:"action_type"`
+	AppID      string `json:"appId"`
+	MetaTag    string `json:"metaTag"`
+}
+
+// serveChannel authorises a websocket client against the issued token and then
+// handles every frame it sends until the connection is closed.
+func (s *Server) serveChannel(conn *websocket.Conn) {
+	lock := &sync.Mutex{}
+	token := conn.Request().URL.Query().Get("token")
+
+	s.mutex.Lock()
+	mode := s.Pairing
+	authorised := s.token != "" && token == s.token
+
+	if !authorised && mode == PairingApprove {
+		if s.token == "" {
+			s.token = newToken()
+		}
+
+		token = s.token
+		authorised = true
+	}
+	s.mutex.Unlock()
+
+	if !authorised {
+		if mode == PairingTimeout {
+			send(conn, lock, "ms.channel.timeOut", nil)
+		} else {
+			send(conn, lock, "ms.channel.unauthorized", nil)
+		}
+
+		_ = conn.Close()
+		return
+	}
+
+	s.mutex.Lock()
+	s.conns[conn] = lock
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		delete(s.conns, conn)
+		s.mutex.Unlock()
+
+		_ = conn.Close()
+	}()
+
+	id := newID()
+
+	send(conn, lock, "ms.channel.connect", map[string]interface{}{
+		"id":    id,
+		"token": token,
+		"clients": []map[string]interface{}{{
+			"id":         id,
+			"deviceName": "samsungtvtest",
+			"isHost":     false,
+			"attributes": map[string]interface{}{"name": "samsungtvtest"},
+		}},
+	})
+
+	if isArtChannel(conn) {
+		send(conn, lock, "ms.channel.ready", nil)
+	}
+
+	for {
+		var raw json.RawMessage
+
+		if err := websocket.JSON.Receive(conn, &raw); err != nil {
+			return
+		}
+
+		s.handleRequest(conn, lock, raw)
+	}
+}
+
+// handleRequest records the frame and answers it the way the TV would.
+func (s *Server) handleRequest(conn *websocket.Conn, lock *sync.Mutex, raw json.RawMessage) {
+	var req request
+	_ = json.Unmarshal(raw, &req)
+
+	s.mutex.Lock()
+	s.requests = append(s.requests, raw)
+	s.mutex.Unlock()
+
+	switch req.Method {
+	case "ms.remote.control":
+		if event, data := s.handleRemoteControl(req); event != "" {
+			s.Emit(event, data)
+		}
+	case "ms.channel.emit":
+		s.handleEmit(conn, lock, req)
+	}
+}
+
+// handleRemoteControl records the key or text, returning the IME event the TV
+// sends in response, if any.
+func (s *Server) handleRemoteControl(req request) (string, interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch req.Params.TypeOfRemote {
+	case "SendRemoteKey":
+		s.keys = append(s.keys, KeyPress{Cmd: req.Params.Cmd, Key: req.Params.DataOfCmd})
+
+		if req.Params.DataOfCmd == "KEY_POWER" && req.Params.Cmd == "Click" {
+			s.togglePower()
+		}
+	case "SendInputString":
+		text, err := base64.StdEncoding.DecodeString(req.Params.Cmd)
+
+		if err != nil {
+			text = []byte(req.Params.Cmd)
+		}
+
+		s.texts = append(s.texts, string(text))
+
+		if s.field.Focused {
+			s.field.Text = string(text)
+			return "ms.remote.imeUpdate", base64.StdEncoding.EncodeToString(text)
+		}
+	case "ProcessMouseDevice":
+		event := MouseEvent{Cmd: req.Params.Cmd, X: req.Params.Position.X, Y: req.Params.Position.Y}
+		s.mouse = append(s.mouse, event)
+
+		if event.Cmd == "Move" {
+			s.cursor.X = min(max(s.cursor.X+event.X, 0), ScreenWidth-1)
+			s.cursor.Y = min(max(s.cursor.Y+event.Y, 0), ScreenHeight-1)
+		}
+	case "SendInputEnd":
+		if s.field.Focused {
+			s.field = TextField{Text: s.field.Text, Submitted: true}
+			return "ms.remote.imeEnd", nil
+		}
+	}
+
+	return "", nil
+}
+
+func (s *Server) handleEmit(conn *websocket.Conn, lock *sync.Mutex, req request) {
+	switch req.Params.Event {
+	case "ed.installedApp.get":
+		s.mutex.Lock()
+		apps := make([]map[string]interface{}, 0, len(s.apps))
+		for _, app := range s.apps {
+			apps = append(apps, map[string]interface{}{
+				"appId":    app.ID,
+				"app_type": app.AppType,
+				"icon":     "/opt/share/webappservice/apps_icon/FirstScreen/" + app.ID + "/250x250.png",
+				"is_lock":  0,
+				"name":     app.Name,
+			})
+		}
+		s.mutex.Unlock()
+
+		send(conn, lock, "ed.installedApp.get", map[string]interface{}{"data": apps})
+
+	case "ed.apps.launch":
+		var data launchData
+		_ = json.Unmarshal(req.Params.Data, &data)
+
+		s.mutex.Lock()
+		s.launches = append(s.launches, Launch{AppID: data.AppID, ActionType: data.ActionType, MetaTag: data.MetaTag})
+		s.mutex.Unlock()
+
+		if !s.launch(data.AppID) {
+			send(conn, lock, "ms.error", map[string]interface{}{"message": "unrecognized method value : ed.apps.launch"})
+			return
+		}
+
+		send(conn, lock, "ed.apps.launch", 200)
+
+	case "art_app_request":
+		s.handleArtRequest(conn, lock, req)
+	}
+}
+
+// launch makes the application visible, hiding every other application.
+func (s *Server) launch(id string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	app, ok := s.apps[id]
+
+	if !ok {
+		return false
+	}
+
+	for _, other := range s.apps {
+		other.Visible = false
+	}
+
+	app.Running = true
+	app.Visible = !app.Hidden
+
+	return true
+}
+
+// togglePower switches the reported power state between on and standby, as
+// the power key does. Callers must hold the mutex.
+func (s *Server) togglePower() {
+	switch s.Device["PowerState"] {
+	case "on":
+		s.Device["PowerState"] = "standby"
+	case "standby":
+		s.Device["PowerState"] = "on"
+	}
+}
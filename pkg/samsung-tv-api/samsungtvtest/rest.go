@@ -0,0 +1,101 @@
+package samsungtvtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// serveDevice answers /api/v2/ with the device information.
+func (s *Server) serveDevice(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/api/v2/" {
+		writeError(w, http.StatusNotFound, "Not found error.")
+		return
+	}
+
+	s.mutex.Lock()
+	device := make(map[string]string, len(s.Device))
+	for key, value := range s.Device {
+		device[key] = value
+	}
+	s.mutex.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"device":    device,
+		"id":        device["id"],
+		"isSupport": `{"remote_available":"true","remote_fourDirections":"true","remote_touchPad":"true","remote_voiceControl":"true"}`,
+		"name":      device["name"],
+		"remote":    "1.0",
+		"type":      device["type"],
+		"uri":       "https://" + r.Host + "/api/v2/",
+		"version":   "2.0.25",
+	})
+}
+
+// serveApplication answers /api/v2/applications/{id}, where GET returns the
+// status, POST runs, DELETE closes and PUT installs the application.
+func (s *Server) serveApplication(w http.ResponseWriter, r *http.Request) {
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v2/applications/"), "/")
+
+	s.mutex.Lock()
+	app, ok := s.apps[id]
+
+	if !ok && r.Method == http.MethodPut {
+		app = &App{ID: id, Name: id, AppType: 2}
+		s.apps[id] = app
+		ok = true
+	}
+	s.mutex.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not found error.")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.mutex.Lock()
+		status := map[string]interface{}{
+			"id":      app.ID,
+			"name":    app.Name,
+			"running": app.Running,
+			"version": "1.0.0",
+			"visible": app.Visible,
+		}
+		s.mutex.Unlock()
+
+		writeJSON(w, http.StatusOK, status)
+
+	case http.MethodPost:
+		s.launch(id)
+		writeJSON(w, http.StatusOK, true)
+
+	case http.MethodDelete:
+		s.mutex.Lock()
+		app.Running = false
+		app.Visible = false
+		s.mutex.Unlock()
+
+		writeJSON(w, http.StatusOK, true)
+
+	case http.MethodPut:
+		writeJSON(w, http.StatusOK, true)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed.")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"code":    status,
+		"message": message,
+		"status":  status,
+	})
+}
@@ -0,0 +1,468 @@
+package samsungtvtest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// This package provides an in-process fake Samsung TV so the websocket, rest
+// and upnp clients can be tested offline. The TV serves the rest api and the
+// websocket channels over TLS like a modern TV does on port 8002, while the
+// upnp services are served over plain http like port 9197.
+
+// PairingMode decides how the fake TV answers a connection without a token.
+type PairingMode int
+
+const (
+	// PairingApprove issues a new token, as if the user selected "Allow".
+	PairingApprove PairingMode = iota
+	// PairingDeny rejects the connection with ms.channel.unauthorized.
+	PairingDeny
+	// PairingTimeout answers with ms.channel.timeOut.
+	PairingTimeout
+)
+
+// KeyPress is a key sent to the TV through the remote control channel.
+type KeyPress struct {
+	Cmd string
+	Key string
+}
+
+// ScreenWidth and ScreenHeight are the size of the screen the pointer of the
+// fake TV moves within.
+const (
+	ScreenWidth  = 1920
+	ScreenHeight = 1080
+)
+
+// MouseEvent is a pointer command sent to the TV, X and Y being the offset of
+// a Move or Scroll.
+type MouseEvent struct {
+	Cmd string
+	X   int
+	Y   int
+}
+
+// Launch is an application launched through the websocket api.
+type Launch struct {
+	AppID      string
+	ActionType string
+	MetaTag    string
+}
+
+// TextField is the text field focused on the TV, if any.
+type TextField struct {
+	Focused bool
+	Text    string
+	// Submitted is true once the text was submitted with SendInputEnd.
+	Submitted bool
+}
+
+// SoapAction is an action invoked on one of the upnp services.
+type SoapAction struct {
+	Service string
+	// URN is the service type of the SOAPAction header, such as
+	// urn:schemas-upnp-org:service:AVTransport:1.
+	URN       string
+	Action    string
+	Arguments map[string]string
+}
+
+// App is an application installed on the fake TV.
+type App struct {
+	ID      string
+	Name    string
+	AppType int
+	Running bool
+	Visible bool
+	// Hidden applications start running without ever becoming visible when
+	// launched, as an application failing to start would.
+	Hidden bool
+}
+
+// Server is a fake Samsung TV, see NewServer.
+type Server struct {
+	// TV serves the rest api and the websocket channels over TLS.
+	TV *httptest.Server
+	// Upnp serves the RenderingControl and AVTransport services.
+	Upnp *httptest.Server
+
+	// Pairing decides how connections without a valid token are answered.
+	Pairing PairingMode
+	// Device holds the fields returned within "device" by the rest api, it
+	// must not be modified once requests are made, see SetDevice.
+	Device map[string]string
+	// Latency delays every rest and upnp response, for testing timeouts. It
+	// must not be modified once requests are made.
+	Latency time.Duration
+
+	mutex     sync.Mutex
+	token     string
+	conns     map[*websocket.Conn]*sync.Mutex
+	keys      []KeyPress
+	texts     []string
+	field     TextField
+	mouse     []MouseEvent
+	launches  []Launch
+	cursor    struct{ X, Y int }
+	requests  []json.RawMessage
+	actions   []SoapAction
+	apps      map[string]*App
+	volume    int
+	muted     bool
+	transport transportState
+	art       artState
+}
+
+// NewServer starts a fake TV, which must be closed once finished with.
+func NewServer() *Server {
+	s := &Server{
+		Device: map[string]string{
+			"FrameTVSupport":   "false",
+			"TokenAuthSupport": "true",
+			"PowerState":       "on",
+			"duid":             "uuid:7b7c3e6a-1b1f-4f4c-9c55-fake00000001",
+			"id":               "uuid:7b7c3e6a-1b1f-4f4c-9c55-fake00000001",
+			"model":            "22_PONTUSM_FTV",
+			"modelName":        "QE55LS03BAUXXU",
+			"name":             "[TV] Samsung Fake TV",
+			"networkType":      "wired",
+			"type":             "Samsung SmartTV",
+			"wifiMac":          "aa:bb:cc:dd:ee:ff",
+		},
+		conns:  map[*websocket.Conn]*sync.Mutex{},
+		apps:   map[string]*App{},
+		volume: 10,
+		art:    artState{mode: "off"},
+		transport: transportState{
+			state:    "NO_MEDIA_PRESENT",
+			playMode: "NORMAL",
+			track:    "0",
+			relTime:  "0:00:00",
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/v2/channels/", websocket.Handler(s.serveChannel))
+	mux.HandleFunc("/api/v2/applications/", s.delayed(s.serveApplication))
+	mux.HandleFunc("/api/v2/", s.delayed(s.serveDevice))
+
+	upnp := http.NewServeMux()
+	upnp.HandleFunc("/upnp/control/", s.delayed(s.serveSoap))
+
+	s.TV = httptest.NewTLSServer(mux)
+	s.Upnp = httptest.NewServer(upnp)
+
+	return s
+}
+
+// delayed waits for the Latency of the TV before answering, giving up once
+// the client goes away.
+func (s *Server) delayed(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.Latency > 0 {
+			select {
+			case <-time.After(s.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		handler(w, r)
+	}
+}
+
+// Close stops the fake TV, closing every open websocket connection.
+func (s *Server) Close() {
+	s.mutex.Lock()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mutex.Unlock()
+
+	s.TV.Close()
+	s.Upnp.Close()
+}
+
+// Host returns the ip address the fake TV listens on.
+func (s *Server) Host() string {
+	host, _, _ := net.SplitHostPort(s.TV.Listener.Addr().String())
+	return host
+}
+
+// Port returns the port serving the rest api and websocket channels.
+func (s *Server) Port() int {
+	return s.TV.Listener.Addr().(*net.TCPAddr).Port
+}
+
+// UpnpPort returns the port serving the upnp services.
+func (s *Server) UpnpPort() int {
+	return s.Upnp.Listener.Addr().(*net.TCPAddr).Port
+}
+
+// RestBaseUrl can be used as the BaseUrl of the rest client.
+func (s *Server) RestBaseUrl(endpoint string) *url.URL {
+	return &url.URL{
+		Scheme: "https",
+		Host:   s.TV.Listener.Addr().String(),
+		Path:   "api/v2/" + strings.Trim(endpoint, "/") + "/",
+	}
+}
+
+// WebsocketBaseUrl can be used as the BaseUrl of the websocket client, the
+// token issued by the fake TV is sent when one has been issued.
+func (s *Server) WebsocketBaseUrl(endpoint string) *url.URL {
+	query := url.Values{"name": {base64.StdEncoding.EncodeToString([]byte("samsungtvtest"))}}
+
+	if token := s.Token(); token != "" {
+		query.Set("token", token)
+	}
+
+	return &url.URL{
+		Scheme:   "wss",
+		Host:     s.TV.Listener.Addr().String(),
+		Path:     "api/v2/channels/" + strings.Trim(endpoint, "/"),
+		RawQuery: query.Encode(),
+	}
+}
+
+// UpnpBaseUrl can be used as the BaseUrl of the upnp client.
+func (s *Server) UpnpBaseUrl(endpoint string) *url.URL {
+	return &url.URL{
+		Scheme: "http",
+		Host:   s.Upnp.Listener.Addr().String(),
+		Path:   "upnp/control/" + strings.Trim(endpoint, "/"),
+	}
+}
+
+// Token returns the token issued by the fake TV, if any.
+func (s *Server) Token() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.token
+}
+
+// SetToken sets the token the fake TV accepts, as if it was issued earlier.
+func (s *Server) SetToken(token string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.token = token
+}
+
+// Keys returns every key sent to the fake TV in order.
+func (s *Server) Keys() []KeyPress {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]KeyPress(nil), s.keys...)
+}
+
+// Texts returns every string sent to the fake TV with SendInputString, decoded.
+func (s *Server) Texts() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]string(nil), s.texts...)
+}
+
+// Launches returns every application launched through the websocket api in
+// order, including applications which are not installed.
+func (s *Server) Launches() []Launch {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]Launch(nil), s.launches...)
+}
+
+// Mouse returns every pointer command received in order.
+func (s *Server) Mouse() []MouseEvent {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]MouseEvent(nil), s.mouse...)
+}
+
+// Cursor returns the position of the pointer, which starts in the top left
+// corner and is kept within the screen.
+func (s *Server) Cursor() (x, y int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.cursor.X, s.cursor.Y
+}
+
+// TextField returns the state of the text field of the fake TV.
+func (s *Server) TextField() TextField {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.field
+}
+
+// FocusTextField focuses a text field holding the text, showing the on screen
+// keyboard. Connected clients are sent the imeStart and imeUpdate events.
+func (s *Server) FocusTextField(text string) {
+	s.mutex.Lock()
+	s.field = TextField{Focused: true, Text: text}
+	s.mutex.Unlock()
+
+	s.Emit("ms.remote.imeStart", "")
+	s.Emit("ms.remote.imeUpdate", base64.StdEncoding.EncodeToString([]byte(text)))
+}
+
+// Requests returns every frame received over the websocket channels in order.
+func (s *Server) Requests() []json.RawMessage {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]json.RawMessage(nil), s.requests...)
+}
+
+// SoapActions returns every upnp action invoked on the fake TV in order.
+func (s *Server) SoapActions() []SoapAction {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]SoapAction(nil), s.actions...)
+}
+
+// AddApp installs the application on the fake TV.
+func (s *Server) AddApp(app App) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.apps[app.ID] = &app
+}
+
+// App returns the current state of the installed application.
+func (s *Server) App(id string) (App, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	app, ok := s.apps[id]
+
+	if !ok {
+		return App{}, false
+	}
+
+	return *app, true
+}
+
+// SetDevice sets a field returned within "device" by the rest api, removing
+// the field when the value is empty.
+func (s *Server) SetDevice(key, value string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if value == "" {
+		delete(s.Device, key)
+		return
+	}
+
+	s.Device[key] = value
+}
+
+// ArtMode returns true when the art app is showing art.
+func (s *Server) ArtMode() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.art.mode == "on"
+}
+
+// Artwork returns the artwork stored by the art app in the order it was
+// uploaded.
+func (s *Server) Artwork() []Artwork {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	artwork := make([]Artwork, 0, len(s.art.artwork))
+	for _, item := range s.art.artwork {
+		artwork = append(artwork, *item)
+	}
+
+	return artwork
+}
+
+// SelectedArtwork returns the content id of the artwork selected through the
+// art app, if any.
+func (s *Server) SelectedArtwork() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.art.selected
+}
+
+// Volume returns the current volume of the fake TV.
+func (s *Server) Volume() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.volume
+}
+
+// Emit sends the event to every connected websocket client.
+func (s *Server) Emit(event string, data interface{}) {
+	s.mutex.Lock()
+	conns := make(map[*websocket.Conn]*sync.Mutex, len(s.conns))
+	for conn, lock := range s.conns {
+		conns[conn] = lock
+	}
+	s.mutex.Unlock()
+
+	for conn, lock := range conns {
+		send(conn, lock, event, data)
+	}
+}
+
+// DropConnections closes every websocket connection, as the TV does when it
+// goes into standby.
+func (s *Server) DropConnections() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+}
+
+func send(conn *websocket.Conn, lock *sync.Mutex, event string, data interface{}) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	frame := map[string]interface{}{"event": event}
+
+	if data != nil {
+		frame["data"] = data
+	}
+
+	_ = websocket.JSON.Send(conn, frame)
+}
+
+func newToken() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+
+	return fmt.Sprint(int(b[0])<<24 | int(b[1])<<16 | int(b[2])<<8 | int(b[3]))
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,292 @@
+package samsungtvtest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MediaDuration is the duration the AVTransport service reports for any media.
+const MediaDuration = "0:03:25.500"
+
+// transportState is the state of the AVTransport service.
+type transportState struct {
+	state    string
+	uri      string
+	meta     string
+	next     string
+	nextMeta string
+	playMode string
+	track    string
+	relTime  string
+}
+
+// upnpError is the UPnP error an action is answered with.
+type upnpError struct {
+	code        int
+	description string
+}
+
+var (
+	errInvalidAction       = &upnpError{401, "Invalid Action"}
+	errInvalidArgs         = &upnpError{402, "Invalid Args"}
+	errOutOfRange          = &upnpError{601, "Argument Value Out of Range"}
+	errSeekModeUnsupported = &upnpError{710, "Seek mode not supported"}
+	errPlayModeUnsupported = &upnpError{712, "Play mode not supported"}
+)
+
+// argument is a single argument of a soap action response, kept in order.
+type argument struct {
+	name  string
+	value string
+}
+
+// serveSoap answers the actions of the RenderingControl and AVTransport
+// services, responding with a UPnP fault to any unknown action or action
+// without an InstanceID.
+func (s *Server) serveSoap(w http.ResponseWriter, r *http.Request) {
+	service := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/upnp/control/"), "1")
+	urn, headerAction, _ := strings.Cut(strings.Trim(r.Header.Get("SOAPAction"), "\""), "#")
+
+	action, arguments, err := parseSoapRequest(r.Body)
+
+	if err != nil {
+		writeFault(w, errInvalidArgs.code, errInvalidArgs.description)
+		return
+	}
+
+	if headerAction != action {
+		writeFault(w, errInvalidAction.code, errInvalidAction.description)
+		return
+	}
+
+	s.mutex.Lock()
+	s.actions = append(s.actions, SoapAction{Service: service, URN: urn, Action: action, Arguments: arguments})
+	s.mutex.Unlock()
+
+	response, fault := []argument(nil), errInvalidAction
+
+	switch _, ok := arguments["InstanceID"]; {
+	case !ok:
+		fault = errInvalidArgs
+	case service == "RenderingControl":
+		response, fault = s.renderingControl(action, arguments)
+	case service == "AVTransport":
+		response, fault = s.avTransport(action, arguments)
+	}
+
+	if fault != nil {
+		writeFault(w, fault.code, fault.description)
+		return
+	}
+
+	writeSoapResponse(w, urn, action, response)
+}
+
+func (s *Server) renderingControl(action string, args map[string]string) ([]argument, *upnpError) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch action {
+	case "GetVolume":
+		return []argument{{"CurrentVolume", strconv.Itoa(s.volume)}}, nil
+	case "SetVolume":
+		volume, err := strconv.Atoi(args["DesiredVolume"])
+		if err != nil || volume < 0 || volume > 100 {
+			return nil, errOutOfRange
+		}
+		s.volume = volume
+		return nil, nil
+	case "GetMute":
+		return []argument{{"CurrentMute", boolArg(s.muted)}}, nil
+	case "SetMute":
+		s.muted = args["DesiredMute"] == "1" || args["DesiredMute"] == "true"
+		return nil, nil
+	}
+
+	return nil, errInvalidAction
+}
+
+func (s *Server) avTransport(action string, args map[string]string) ([]argument, *upnpError) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch action {
+	case "SetAVTransportURI":
+		s.transport.state = "STOPPED"
+		s.transport.uri, s.transport.meta = args["CurrentURI"], args["CurrentURIMetaData"]
+		s.transport.track, s.transport.relTime = "1", "0:00:00"
+		return nil, nil
+	case "SetNextAVTransportURI":
+		s.transport.next, s.transport.nextMeta = args["NextURI"], args["NextURIMetaData"]
+		return nil, nil
+	case "Play":
+		s.transport.state = "PLAYING"
+		return nil, nil
+	case "Pause":
+		s.transport.state = "PAUSED_PLAYBACK"
+		return nil, nil
+	case "Stop":
+		s.transport.state = "STOPPED"
+		return nil, nil
+	case "Next", "Previous":
+		return nil, nil
+	case "Seek":
+		switch args["Unit"] {
+		case "REL_TIME":
+			s.transport.relTime = args["Target"]
+		case "TRACK_NR":
+			s.transport.track, s.transport.relTime = args["Target"], "0:00:00"
+		default:
+			return nil, errSeekModeUnsupported
+		}
+		return nil, nil
+	case "SetPlayMode":
+		switch args["NewPlayMode"] {
+		case "NORMAL", "SHUFFLE", "REPEAT_ONE", "REPEAT_ALL":
+			s.transport.playMode = args["NewPlayMode"]
+		default:
+			return nil, errPlayModeUnsupported
+		}
+		return nil, nil
+	case "GetTransportSettings":
+		return []argument{
+			{"PlayMode", s.transport.playMode},
+			{"RecQualityMode", "NOT_IMPLEMENTED"},
+		}, nil
+	case "GetDeviceCapabilities":
+		return []argument{
+			{"PlayMedia", "NETWORK,UNKNOWN"},
+			{"RecMedia", "NOT_IMPLEMENTED"},
+			{"RecQualityModes", "NOT_IMPLEMENTED"},
+		}, nil
+	case "GetCurrentTransportActions":
+		actions := map[string]string{
+			"PLAYING":         "Pause,Stop,Seek",
+			"PAUSED_PLAYBACK": "Play,Stop,Seek",
+			"STOPPED":         "Play,Seek",
+		}
+		return []argument{{"Actions", actions[s.transport.state]}}, nil
+	case "GetTransportInfo":
+		return []argument{
+			{"CurrentTransportState", s.transport.state},
+			{"CurrentTransportStatus", "OK"},
+			{"CurrentSpeed", "1"},
+		}, nil
+	case "GetPositionInfo":
+		return []argument{
+			{"Track", s.transport.track},
+			{"TrackDuration", MediaDuration},
+			{"TrackMetaData", s.transport.meta},
+			{"TrackURI", s.transport.uri},
+			{"RelTime", s.transport.relTime},
+			{"AbsTime", "NOT_IMPLEMENTED"},
+			{"RelCount", "2147483647"},
+			{"AbsCount", "2147483647"},
+		}, nil
+	case "GetMediaInfo":
+		return []argument{
+			{"NrTracks", "1"},
+			{"MediaDuration", MediaDuration},
+			{"CurrentURI", s.transport.uri},
+			{"CurrentURIMetaData", s.transport.meta},
+			{"NextURI", s.transport.next},
+			{"NextURIMetaData", s.transport.nextMeta},
+			{"PlayMedium", "NETWORK"},
+			{"RecordMedium", "NOT_IMPLEMENTED"},
+			{"WriteStatus", "NOT_IMPLEMENTED"},
+		}, nil
+	}
+
+	return nil, errInvalidAction
+}
+
+// parseSoapRequest returns the action within the soap body and the text of
+// every argument of the action.
+func parseSoapRequest(body io.Reader) (string, map[string]string, error) {
+	decoder := xml.NewDecoder(body)
+
+	var action string
+	var current string
+	arguments := map[string]string{}
+	depth := 0
+
+	for {
+		token, err := decoder.Token()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return "", nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+
+			// Envelope (1) > Body (2) > Action (3) > Argument (4)
+			switch depth {
+			case 3:
+				action = t.Name.Local
+			case 4:
+				current = t.Name.Local
+				arguments[current] = ""
+			}
+		case xml.CharData:
+			if depth == 4 {
+				arguments[current] += string(t)
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+
+	if action == "" {
+		return "", nil, fmt.Errorf("soap request has no action")
+	}
+
+	return action, arguments, nil
+}
+
+func writeSoapResponse(w http.ResponseWriter, urn, action string, arguments []argument) {
+	var body strings.Builder
+
+	for _, arg := range arguments {
+		body.WriteString("<" + arg.name + ">")
+		_ = xml.EscapeText(&body, []byte(arg.value))
+		body.WriteString("</" + arg.name + ">")
+	}
+
+	w.Header().Set("Content-Type", `text/xml; charset="utf-8"`)
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?>`+
+		`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">`+
+		`<s:Body><u:%sResponse xmlns:u="%s">%s</u:%sResponse></s:Body></s:Envelope>`,
+		action, urn, body.String(), action)
+}
+
+func writeFault(w http.ResponseWriter, code int, description string) {
+	w.Header().Set("Content-Type", `text/xml; charset="utf-8"`)
+	w.WriteHeader(http.StatusInternalServerError)
+
+	_, _ = fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?>`+
+		`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">`+
+		`<s:Body><s:Fault><faultcode>s:Client</faultcode><faultstring>UPnPError</faultstring>`+
+		`<detail><UPnPError xmlns="urn:schemas-upnp-org:control-1-0">`+
+		`<errorCode>%d</errorCode><errorDescription>%s</errorDescription>`+
+		`</UPnPError></detail></s:Fault></s:Body></s:Envelope>`, code, description)
+}
+
+func boolArg(b bool) string {
+	if b {
+		return "1"
+	}
+
+	return "0"
+}
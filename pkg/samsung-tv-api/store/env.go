@@ -0,0 +1,49 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// ErrReadOnly is returned when saving a token to a store which cannot be
+// written to.
+var ErrReadOnly = errors.New("token store is read only")
+
+// EnvPrefix is the prefix of the environment variables read by EnvStore.
+const EnvPrefix = "SAMSUNG_TV_TOKEN"
+
+// EnvStore reads tokens from the environment, which suits containers and CI.
+// The token of a device is read from SAMSUNG_TV_TOKEN_<ID>, where ID is the
+// device id upper cased with every other character replaced by an underscore,
+// falling back to SAMSUNG_TV_TOKEN. Saving is not supported.
+type EnvStore struct{}
+
+// EnvName returns the environment variable holding the token of the device.
+func EnvName(deviceID string) string {
+	name := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, deviceID)
+
+	return EnvPrefix + "_" + name
+}
+
+func (EnvStore) Token(deviceID string) (string, error) {
+	if token := os.Getenv(EnvName(deviceID)); token != "" {
+		return token, nil
+	}
+
+	if token := os.Getenv(EnvPrefix); token != "" {
+		return token, nil
+	}
+
+	return "", ErrNotFound
+}
+
+func (EnvStore) SaveToken(deviceID, token string) error {
+	return ErrReadOnly
+}
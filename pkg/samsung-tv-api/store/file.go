@@ -0,0 +1,156 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// FileStore keeps tokens in a JSON file readable only by the current user,
+// writes are atomic and guarded by a lock file so several processes can share
+// the same store.
+type FileStore struct {
+	Path string
+}
+
+// NewFileStore returns a store kept within the user config directory, which
+// is $XDG_CONFIG_HOME/samsung-tv-api/tokens.json on Linux.
+func NewFileStore() (*FileStore, error) {
+	dir, err := os.UserConfigDir()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileStore{Path: filepath.Join(dir, "samsung-tv-api", "tokens.json")}, nil
+}
+
+func (f *FileStore) Token(deviceID string) (string, error) {
+	unlock, err := f.lock()
+
+	if err != nil {
+		return "", err
+	}
+
+	defer unlock()
+
+	tokens, err := f.read()
+
+	if err != nil {
+		return "", err
+	}
+
+	token, ok := tokens[deviceID]
+
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return token, nil
+}
+
+func (f *FileStore) SaveToken(deviceID, token string) error {
+	unlock, err := f.lock()
+
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	tokens, err := f.read()
+
+	if err != nil {
+		return err
+	}
+
+	tokens[deviceID] = token
+	return f.write(tokens)
+}
+
+// lock takes an exclusive lock on the lock file next to the store, creating
+// the directory of the store when missing.
+func (f *FileStore) lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0700); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(f.Path+".lock", os.O_RDWR|os.O_CREATE, 0600)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockFile(file); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return func() {
+		_ = unlockFile(file)
+		_ = file.Close()
+	}, nil
+}
+
+// read returns the tokens held within the store, which is empty when the file
+// does not exist yet.
+func (f *FileStore) read() (map[string]string, error) {
+	tokens := map[string]string{}
+	content, err := os.ReadFile(f.Path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(content) == 0 {
+		return tokens, nil
+	}
+
+	return tokens, json.Unmarshal(content, &tokens)
+}
+
+// write replaces the store by writing the tokens to a temporary file which is
+// then renamed over the store.
+func (f *FileStore) write(tokens map[string]string) error {
+	content, err := json.MarshalIndent(tokens, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), filepath.Base(f.Path)+".*.tmp")
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if err := tmp.Chmod(0600); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), f.Path)
+}
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package store
+
+import "os"
+
+// lockFile is a no-op where flock is not available, writes are still atomic.
+func lockFile(file *os.File) error {
+	return nil
+}
+
+func unlockFile(file *os.File) error {
+	return nil
+}
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"errors"
+	"sync"
+)
+
+// This package covers where the tokens issued by TVs are kept between runs, so
+// the user is not asked to allow the connection every time.
+
+// ErrNotFound is returned when no token is stored for the device.
+var ErrNotFound = errors.New("no token stored for the device")
+
+// TokenStore loads and saves the token issued by a TV, keyed by an identifier
+// unique to the device.
+type TokenStore interface {
+	// Token returns the token stored for the device, or ErrNotFound.
+	Token(deviceID string) (string, error)
+	// SaveToken stores the token for the device, replacing any existing token.
+	SaveToken(deviceID, token string) error
+}
+
+// MemoryStore keeps tokens in memory for the lifetime of the process.
+type MemoryStore struct {
+	mutex  sync.Mutex
+	tokens map[string]string
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tokens: map[string]string{}}
+}
+
+func (m *MemoryStore) Token(deviceID string) (string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	token, ok := m.tokens[deviceID]
+
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return token, nil
+}
+
+func (m *MemoryStore) SaveToken(deviceID, token string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.tokens[deviceID] = token
+	return nil
+}
@@ -0,0 +1,57 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "samsung-tv-api", "tokens.json")
+	tokens := &FileStore{Path: path}
+
+	_, err := tokens.Token("uuid:1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.NoError(t, tokens.SaveToken("uuid:1", "111"))
+	assert.NoError(t, tokens.SaveToken("uuid:2", "222"))
+	assert.NoError(t, tokens.SaveToken("uuid:1", "333"))
+
+	token, err := tokens.Token("uuid:1")
+	assert.NoError(t, err)
+	assert.Equal(t, "333", token)
+
+	token, err = (&FileStore{Path: path}).Token("uuid:2")
+	assert.NoError(t, err)
+	assert.Equal(t, "222", token)
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// only the store and its lock file are left behind.
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestEnvStore(t *testing.T) {
+	assert.Equal(t, "SAMSUNG_TV_TOKEN_UUID_0F1E_AB", EnvName("uuid:0f1e-ab"))
+
+	_, err := EnvStore{}.Token("uuid:0f1e-ab")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	t.Setenv(EnvPrefix, "fallback")
+	token, err := EnvStore{}.Token("uuid:0f1e-ab")
+	assert.NoError(t, err)
+	assert.Equal(t, "fallback", token)
+
+	t.Setenv(EnvName("uuid:0f1e-ab"), "device")
+	token, err = EnvStore{}.Token("uuid:0f1e-ab")
+	assert.NoError(t, err)
+	assert.Equal(t, "device", token)
+
+	assert.ErrorIs(t, EnvStore{}.SaveToken("uuid:0f1e-ab", "1"), ErrReadOnly)
+}
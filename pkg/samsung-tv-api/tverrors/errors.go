@@ -0,0 +1,96 @@
+package tverrors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// This package holds the errors returned by the websocket, rest and upnp
+// clients so callers can react to failures reported by the TV with errors.Is
+// and errors.As.
+
+var (
+	// ErrUnauthorized is returned when the TV rejects the client, for example
+	// when the token is no longer valid and the device must be paired again.
+	ErrUnauthorized = errors.New("unauthorized by the TV")
+	// ErrAppNotFound is returned when the requested application is not
+	// installed on the TV.
+	ErrAppNotFound = errors.New("application not found on the TV")
+	// ErrPairingTimeout is returned when the user did not respond to the
+	// request to allow the connection shown on the TV in time.
+	ErrPairingTimeout = errors.New("timed out waiting for the connection to be allowed on the TV")
+	// ErrCertificateMismatch is returned when the certificate presented by the
+	// TV is not the certificate trusted when pairing, either the TV was reset
+	// or another device is impersonating it.
+	ErrCertificateMismatch = errors.New("tv certificate does not match the trusted certificate")
+	// ErrPowerTimeout is returned when the TV did not reach the requested
+	// power state in time.
+	ErrPowerTimeout = errors.New("timed out waiting for the tv to change power state")
+	// ErrAppTimeout is returned when an application did not become visible
+	// or close in time.
+	ErrAppTimeout = errors.New("timed out waiting for the application to change state")
+)
+
+// TVError is an error event sent by the TV over the websocket api, such as
+// ms.error or ms.channel.unauthorized.
+type TVError struct {
+	Event   string
+	Message string
+}
+
+func (e *TVError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("tv reported %s", e.Event)
+	}
+
+	return fmt.Sprintf("tv reported %s: %s", e.Event, e.Message)
+}
+
+// Unwrap returns ErrUnauthorized for events rejecting the client, allowing
+// errors.Is(err, ErrUnauthorized) regardless of the api which failed, and
+// ErrPairingTimeout when the connection was not allowed in time.
+func (e *TVError) Unwrap() error {
+	switch e.Event {
+	case "ms.channel.unauthorized":
+		return ErrUnauthorized
+	case "ms.channel.timeOut":
+		return ErrPairingTimeout
+	}
+
+	return nil
+}
+
+// HTTPError is returned by the rest api for any response which is not
+// successful, Err holds the matching sentinel error if any.
+type HTTPError struct {
+	StatusCode int
+	Message    string
+	Err        error
+}
+
+func (e *HTTPError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("rest request failed with status %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("rest request failed with status %d: %s", e.StatusCode, e.Message)
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// CertificateError is returned when the TV presents a certificate other than
+// the pinned certificate, holding both SHA-256 fingerprints.
+type CertificateError struct {
+	Expected string
+	Actual   string
+}
+
+func (e *CertificateError) Error() string {
+	return fmt.Sprintf("tv certificate %s does not match the trusted certificate %s", e.Actual, e.Expected)
+}
+
+func (e *CertificateError) Unwrap() error {
+	return ErrCertificateMismatch
+}
@@ -1,27 +1,80 @@
 package websocket
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/keys"
 	"golang.org/x/net/websocket"
 )
 
+// RemoteControlChannel is the channel opened when no Channel is configured.
+const RemoteControlChannel = "samsung.remote.control"
+
+// ErrNotConnected is returned when a command is sent or awaited without an
+// open connection to the TV.
+var ErrNotConnected = errors.New("websocket is not connected")
+
 type SamsungWebsocket struct {
-	BaseUrl         func(string) *url.URL
-	KeyPressDelay   int
-	conn            *websocket.Conn
-	readMutex       sync.Mutex
-	writeMutex      sync.Mutex
-	isReadWriteMode int32
+	BaseUrl func(string) *url.URL
+	// Channel is the channel endpoint opened on the TV, RemoteControlChannel
+	// is used when empty.
+	Channel       string
+	KeyPressDelay int
+	// AllowUnknownKeys sends keys missing from the keys registry, which are
+	// otherwise rejected with keys.ErrUnknownKey.
+	AllowUnknownKeys bool
+	// IMETimeout is how long TypeText waits for a text field to be focused,
+	// DefaultIMETimeout when zero.
+	IMETimeout time.Duration
+	// Timeout bounds calls awaiting a response whose context has no deadline,
+	// DefaultTimeout when zero.
+	Timeout time.Duration
+	// PointerPolicy configures the size of the screen and the smoothing of
+	// the pointer.
+	PointerPolicy PointerPolicy
+	// TLSConfig is used for wss connections, the self-signed certificate of
+	// the TV is not verified when nil.
+	TLSConfig *tls.Config
+	// OnConnect is called with the connecting response every time a connection
+	// is opened, including when reconnecting.
+	OnConnect func(*ConnectionResponse)
+	// IDExtractor returns the id used to correlate an event with the request
+	// it answers, the top level "id" of the frame is used when nil.
+	IDExtractor func(Event) string
+	conn        *websocket.Conn
+	connMutex   sync.Mutex
+	writeMutex  sync.Mutex
+	// done is closed once the reader of the current connection exits, readErr
+	// then holds the reason the reader stopped.
+	done        chan struct{}
+	readErr     error
+	subsMutex   sync.Mutex
+	subscribers map[string][]chan Event
+	seen        map[string]Event
+	// ime is guarded by subsMutex.
+	ime     IMEState
+	pending []*pendingCall
+	// state, reconnect and queue are guarded by connMutex.
+	state           ConnectionState
+	reconnect       *ReconnectPolicy
+	reconnectCancel context.CancelFunc
+	queue           [][]byte
+	// pointer is the position of the pointer once known, guarded by
+	// pointerMutex.
+	pointer      Point
+	pointerKnown bool
+	pointerMutex sync.Mutex
 }
 
 type Request struct {
@@ -32,137 +85,346 @@ type Request struct {
 // OpenConnection will attempt to open a websocket connection with the TV.
 // Reading the connecting JSON response from the TV.
 //
+// Once connected every following frame is read by a single background reader
+// and dispatched to subscribers, see Subscribe.
+//
 // This will disable TLS validation on the self-signed certificate created
-// and managed by the TV.
+// and managed by the TV, unless a TLSConfig is provided.
 func (s *SamsungWebsocket) OpenConnection() (*ConnectionResponse, error) {
-	if s.conn != nil {
-		_ = s.conn.Close()
-		s.conn = nil
+	return s.OpenConnectionContext(context.Background())
+}
+
+// OpenConnectionContext is OpenConnection bound to the provided context, which
+// covers dialing the TV and reading the connecting response.
+func (s *SamsungWebsocket) OpenConnectionContext(ctx context.Context) (*ConnectionResponse, error) {
+	s.stopReconnect()
+	s.setState(StateConnecting, nil)
+
+	resp, err := s.open(ctx)
+
+	if err != nil {
+		s.setState(StateDisconnected, err)
 	}
 
-	s.readMutex = sync.Mutex{}
-	s.writeMutex = sync.Mutex{}
+	return resp, err
+}
+
+// open dials the TV, reads the connecting response and starts the background
+// reader for the new connection, replacing any existing connection.
+func (s *SamsungWebsocket) open(ctx context.Context) (*ConnectionResponse, error) {
+	_ = s.closeConnection()
 
 	origin := "http://localhost/"
-	u := s.BaseUrl("samsung.remote.control").String()
+	channel := s.Channel
+	if channel == "" {
+		channel = RemoteControlChannel
+	}
 
-	config, _ := websocket.NewConfig(u, origin)
-	config.TlsConfig = &tls.Config{InsecureSkipVerify: true}
+	u := s.BaseUrl(channel).String()
 
-	ws, err := websocket.DialConfig(config)
+	config, err := websocket.NewConfig(u, origin)
 
 	if err != nil {
 		return nil, err
 	}
 
-	s.conn = ws
+	config.TlsConfig = s.TLSConfig
+
+	if config.TlsConfig == nil {
+		config.TlsConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	ws, stop, err := dialContext(ctx, config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var msg []byte
+	if err := websocket.Message.Receive(ws, &msg); err != nil {
+		_ = ws.Close()
+		return nil, contextError(ctx, err)
+	}
+
+	if !stop() {
+		_ = ws.Close()
+		return nil, ctx.Err()
+	}
+
+	_ = ws.SetDeadline(time.Time{})
 
 	var val ConnectionResponse
-	readErr := s.readJSON(&val)
+	if err := json.Unmarshal(msg, &val); err != nil {
+		_ = ws.Close()
+		return nil, err
+	}
 
-	return &val, readErr
-}
+	if ev, err := decodeEvent(msg); err == nil && ev.Err() != nil {
+		_ = ws.Close()
+		return nil, ev.Err()
+	}
 
-func (s *SamsungWebsocket) WaitFor(event string) {
-	fmt.Println("Waiting for ", event)
-	origin := "http://localhost/"
-	u := s.BaseUrl("samsung.remote.control").String()
+	s.subsMutex.Lock()
+	s.seen = map[string]Event{}
+	s.ime = IMEState{}
+	s.subsMutex.Unlock()
 
-	config, _ := websocket.NewConfig(u, origin)
-	config.TlsConfig = &tls.Config{InsecureSkipVerify: true}
+	if ev, err := decodeEvent(msg); err == nil {
+		s.dispatch(ev)
+	}
 
-	var ws *websocket.Conn
-	var err error
+	done := make(chan struct{})
 
-	for {
-		ws, err = websocket.DialConfig(config)
-		if err == nil {
-			break
+	s.connMutex.Lock()
+	// the context is checked while holding the lock so a reconnect cancelled
+	// by Disconnect can never leave a connection behind.
+	if err := ctx.Err(); err != nil {
+		s.connMutex.Unlock()
+		_ = ws.Close()
+		return nil, err
+	}
+	s.conn = ws
+	s.done = done
+	s.readErr = nil
+	s.connMutex.Unlock()
+
+	go s.readLoop(ws, done)
+
+	s.setState(StateConnected, nil)
+
+	if s.OnConnect != nil {
+		s.OnConnect(&val)
+	}
+
+	s.flushQueue(ctx)
+
+	return &val, nil
+}
+
+// dialContext opens the underlying connection and performs the websocket
+// handshake honouring the deadline and cancellation of the context. The
+// returned stop function must be called once the caller is done with the
+// context, returning false if the context already closed the connection.
+func dialContext(ctx context.Context, config *websocket.Config) (*websocket.Conn, func() bool, error) {
+	host := config.Location.Host
+
+	if config.Location.Port() == "" {
+		host = net.JoinHostPort(config.Location.Hostname(), "80")
+
+		if config.Location.Scheme == "wss" {
+			host = net.JoinHostPort(config.Location.Hostname(), "443")
 		}
 	}
-	defer ws.Close()
 
-	for {
-		var message string
-		if err := websocket.Message.Receive(ws, &message); err != nil {
-			log.Println("Error reading message:", err)
-			break
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if config.Location.Scheme == "wss" {
+		tlsConfig := config.TlsConfig.Clone()
+
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = config.Location.Hostname()
 		}
-		// Log the received message
-		log.Println("Received message:", message)
-		if strings.Contains(message, event) {
-			return
+
+		tlsConn := tls.Client(conn, tlsConfig)
+
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, nil, err
 		}
+
+		conn = tlsConn
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+
+	ws, err := websocket.NewClient(config, conn)
+
+	if err != nil {
+		stop()
+		_ = conn.Close()
+		return nil, nil, contextError(ctx, err)
 	}
+
+	return ws, stop, nil
 }
 
-// sendJSONReceiveJSON will attempt to first send the command to the websocket server (TV)
-// and then receive content back from the TV, unmarshalling the response to the output
-// interface.
-func (s *SamsungWebsocket) sendJSONReceiveJSON(command interface{}, output interface{}) error {
-	s.writeMutex.Lock()
-	s.readMutex.Lock()
+// contextError prefers the error of the context when it has been cancelled or
+// its deadline exceeded, as that is the reason the network call failed.
+func contextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	return err
+}
+
+// sleep pauses for the given duration, returning early with the context error
+// if the context is done first.
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
 
-	atomic.AddInt32(&s.isReadWriteMode, 1)
+// readLoop reads every frame from the given connection until it is closed,
+// dispatching each decoded frame to subscribers.
+func (s *SamsungWebsocket) readLoop(ws *websocket.Conn, done chan struct{}) {
+	var err error
 
 	defer func() {
-		atomic.AddInt32(&s.isReadWriteMode, -1)
-		s.writeMutex.Unlock()
-		s.readMutex.Unlock()
+		s.connMutex.Lock()
+		if s.done == done {
+			s.readErr = err
+		}
+		// the connection is still current when it was not closed through
+		// Disconnect or replaced by a new connection.
+		dropped := s.conn == ws
+		if dropped {
+			s.conn = nil
+		}
+		s.connMutex.Unlock()
+		close(done)
+
+		if dropped {
+			s.connectionDropped(err)
+		}
 	}()
 
-	err := s.sendJSON(command)
+	for {
+		var msg []byte
+		if err = websocket.Message.Receive(ws, &msg); err != nil {
+			log.Println("websocket reader stopped:", err)
+			return
+		}
 
-	if err != nil {
-		return err
+		ev, decodeErr := decodeEvent(msg)
+		if decodeErr != nil {
+			log.Println("unable to decode websocket frame:", decodeErr)
+			continue
+		}
+
+		s.dispatch(ev)
 	}
-	return s.readJSON(output)
 }
 
-// sendJSON will convert the provided command interface to JSON and then
-// into a byte array stream, sending it to the server.
-func (s *SamsungWebsocket) sendJSON(command interface{}) error {
-	if atomic.LoadInt32(&s.isReadWriteMode) != 1 {
-		s.writeMutex.Lock()
-		defer s.writeMutex.Unlock()
-	}
+// closed returns a channel which is closed once the reader of the current
+// connection has stopped, along with the current connection.
+func (s *SamsungWebsocket) closed() (<-chan struct{}, *websocket.Conn) {
+	s.connMutex.Lock()
+	defer s.connMutex.Unlock()
 
-	msg, err := json.Marshal(command)
+	return s.done, s.conn
+}
 
-	if err != nil {
-		return err
+// connectionError returns the reason the reader of the current connection
+// stopped, falling back to ErrNotConnected.
+func (s *SamsungWebsocket) connectionError() error {
+	s.connMutex.Lock()
+	defer s.connMutex.Unlock()
+
+	if s.readErr != nil {
+		return s.readErr
 	}
 
-	_, err = s.conn.Write(msg)
+	return ErrNotConnected
+}
 
-	return err
+// WaitFor blocks until the given event has been read on the current connection,
+// returning straight away if it was already read since the connection opened.
+// A *tverrors.TVError is returned if the TV rejects the client while waiting.
+func (s *SamsungWebsocket) WaitFor(event string) error {
+	return s.WaitForContext(context.Background(), event)
 }
 
-// read will read the next frame of data from the websocket.
-// Returning the byte array back.
-func (s *SamsungWebsocket) read() ([]byte, error) {
-	if atomic.LoadInt32(&s.isReadWriteMode) != 1 {
-		fmt.Println("atomic read lock not set, locking read", atomic.LoadInt32(&s.isReadWriteMode))
-		s.readMutex.Lock()
-		defer s.readMutex.Unlock()
+// WaitForContext is WaitFor bound to the provided context.
+func (s *SamsungWebsocket) WaitForContext(ctx context.Context, event string) error {
+	log.Printf("Waiting for %s\n", event)
+
+	sub := s.Subscribe(event)
+	defer s.Unsubscribe(sub)
+
+	unauthorized := s.Subscribe(UnauthorizedEvent)
+	defer s.Unsubscribe(unauthorized)
+
+	if _, ok := s.lastEvent(event); ok {
+		return nil
+	}
+
+	if ev, ok := s.lastEvent(UnauthorizedEvent); ok {
+		return ev.Err()
 	}
 
-	var data []byte
-	err := websocket.Message.Receive(s.conn, &data)
+	done, conn := s.closed()
+
+	if conn == nil {
+		return ErrNotConnected
+	}
+
+	select {
+	case <-sub:
+		return nil
+	case ev := <-unauthorized:
+		return ev.Err()
+	case <-done:
+		return s.connectionError()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
 
-	return data, err
+// Send will send the provided request to the TV without waiting for a response.
+func (s *SamsungWebsocket) Send(ctx context.Context, req interface{}) error {
+	return s.sendJSON(ctx, req)
 }
 
-// readJSON will read the next frame of data from the websocket and attempt
-// to convert the content to JSON and unmarshal to the given type.
-func (s *SamsungWebsocket) readJSON(val interface{}) error {
-	msg, err := s.read()
+// sendJSON will convert the provided command interface to JSON and then
+// into a byte array stream, sending it to the server. The write is bound
+// to the deadline of the context, if any.
+func (s *SamsungWebsocket) sendJSON(ctx context.Context, command interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	msg, err := json.Marshal(command)
 
 	if err != nil {
 		return err
 	}
 
-	return json.Unmarshal(msg, val)
+	_, conn := s.closed()
+
+	if conn == nil {
+		return s.enqueue(msg)
+	}
+
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetWriteDeadline(deadline)
+		defer func() { _ = conn.SetWriteDeadline(time.Time{}) }()
+	}
+
+	_, err = conn.Write(msg)
+
+	return contextError(ctx, err)
 }
 
 // GetApplicationsList will request the TV to send over the listed applications
@@ -175,6 +437,11 @@ func (s *SamsungWebsocket) readJSON(val interface{}) error {
 //
 // DOC: TODO
 func (s *SamsungWebsocket) GetApplicationsList() (ApplicationsResponse, error) {
+	return s.GetApplicationsListContext(context.Background())
+}
+
+// GetApplicationsListContext is GetApplicationsList bound to the provided context.
+func (s *SamsungWebsocket) GetApplicationsListContext(ctx context.Context) (ApplicationsResponse, error) {
 	log.Println("Getting applications lists via ws api")
 
 	var output ApplicationsResponse
@@ -187,15 +454,13 @@ func (s *SamsungWebsocket) GetApplicationsList() (ApplicationsResponse, error) {
 		},
 	}
 
-	for {
-		err := s.sendJSONReceiveJSON(req, &output)
-		if err != nil {
-			return output, err
-		}
-		if output.Event == "ed.installedApp.get" {
-			return output, err
-		}
+	ev, err := s.Call(ctx, req, Expect{Event: "ed.installedApp.get"})
+
+	if err != nil {
+		return output, err
 	}
+
+	return output, ev.Decode(&output)
 }
 
 // RunApplication will tell the TV via the web socket api to run a given application
@@ -205,6 +470,11 @@ func (s *SamsungWebsocket) GetApplicationsList() (ApplicationsResponse, error) {
 //   - This requires to be tested, it has not been ran to close any applications yet.
 //   - This has to been tested with any bad input, should be regarded as not stable.
 func (s *SamsungWebsocket) RunApplication(appId, appType, metaTag string) error {
+	return s.RunApplicationContext(context.Background(), appId, appType, metaTag)
+}
+
+// RunApplicationContext is RunApplication bound to the provided context.
+func (s *SamsungWebsocket) RunApplicationContext(ctx context.Context, appId, appType, metaTag string) error {
 	log.Printf("Running application %s via ws api\n", appId)
 
 	if appType == "" {
@@ -226,7 +496,7 @@ func (s *SamsungWebsocket) RunApplication(appId, appType, metaTag string) error
 		},
 	}
 
-	return s.sendJSON(req)
+	return s.sendJSON(ctx, req)
 }
 
 // SendClick will command the TV to perform a click on a given key.
@@ -234,7 +504,12 @@ func (s *SamsungWebsocket) RunApplication(appId, appType, metaTag string) error
 // TODO
 //   - This has to been tested with any bad input, should be regarded as not stable.
 func (s *SamsungWebsocket) SendClick(key string) error {
-	return s.SendKey(key, 1, "Click")
+	return s.SendClickContext(context.Background(), key)
+}
+
+// SendClickContext is SendClick bound to the provided context.
+func (s *SamsungWebsocket) SendClickContext(ctx context.Context, key string) error {
+	return s.SendKeyContext(ctx, key, 1, "Click")
 }
 
 // SendKey will command the TV to perform a given action on a given key, this
@@ -243,11 +518,21 @@ func (s *SamsungWebsocket) SendClick(key string) error {
 // TODO
 //   - This has to been tested with any bad input, should be regarded as not stable.
 func (s *SamsungWebsocket) SendKey(key string, times int, cmd string) error {
+	return s.SendKeyContext(context.Background(), key, times, cmd)
+}
 
+// SendKeyContext is SendKey bound to the provided context, the delay between
+// key presses is cut short if the context is done. Keys missing from the keys
+// registry are rejected unless AllowUnknownKeys is set.
+func (s *SamsungWebsocket) SendKeyContext(ctx context.Context, key string, times int, cmd string) error {
 	if cmd == "" {
 		cmd = "Click"
 	}
 
+	if !s.AllowUnknownKeys && !keys.IsKey(key) {
+		return fmt.Errorf("%w %q", keys.ErrUnknownKey, key)
+	}
+
 	log.Printf("Sending key %s with command %s, %d times via ws api\n", key, cmd, times)
 
 	for i := 0; i < times; i++ {
@@ -263,19 +548,29 @@ func (s *SamsungWebsocket) SendKey(key string, times int, cmd string) error {
 			},
 		}
 
-		err := s.sendJSON(req)
+		err := s.sendJSON(ctx, req)
 
 		if err != nil {
 			return err
 		}
 
-		time.Sleep(time.Duration(s.KeyPressDelay) * time.Millisecond)
+		if err := sleep(ctx, time.Duration(s.KeyPressDelay)*time.Millisecond); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// SendText will send the provided base64 encoded text to the currently focused
+// input field on the TV, whether or not a field is focused. See TypeText for
+// sending plain text once a field is focused.
 func (s *SamsungWebsocket) SendText(text string) error {
+	return s.SendTextContext(context.Background(), text)
+}
+
+// SendTextContext is SendText bound to the provided context.
+func (s *SamsungWebsocket) SendTextContext(ctx context.Context, text string) error {
 	log.Printf("Sending text %s via ws api\n", text)
 	var req = Request{
 		Method: "ms.remote.control",
@@ -286,7 +581,7 @@ func (s *SamsungWebsocket) SendText(text string) error {
 		},
 	}
 
-	err := s.sendJSON(req)
+	err := s.sendJSON(ctx, req)
 
 	if err != nil {
 		return err
@@ -302,18 +597,28 @@ func (s *SamsungWebsocket) SendText(text string) error {
 //   - This requires to be tested, it has not been ran to close any applications yet.
 //   - This has to been tested with any bad input, should be regarded as not stable.
 func (s *SamsungWebsocket) HoldKey(key string, seconds int) error {
+	return s.HoldKeyContext(context.Background(), key, seconds)
+}
+
+// HoldKeyContext is HoldKey bound to the provided context. The key is still
+// released if the context is done while it is being held.
+func (s *SamsungWebsocket) HoldKeyContext(ctx context.Context, key string, seconds int) error {
 	log.Printf("Sending hold key %s for %d seconds via ws api\n", key, seconds)
 
-	pressErr := s.SendKey(key, 1, "Press")
+	pressErr := s.SendKeyContext(ctx, key, 1, "Press")
 
 	if pressErr != nil {
 		return pressErr
 	}
 
-	time.Sleep(time.Duration(seconds) * time.Second)
+	holdErr := sleep(ctx, time.Duration(seconds)*time.Second)
 
 	log.Printf("Sending release key %s via ws api\n", key)
-	releaseErr := s.SendKey(key, 1, "Release")
+	releaseErr := s.SendKeyContext(context.WithoutCancel(ctx), key, 1, "Release")
+
+	if holdErr != nil {
+		return holdErr
+	}
 
 	if releaseErr != nil {
 		return releaseErr
@@ -328,25 +633,32 @@ func (s *SamsungWebsocket) HoldKey(key string, seconds int) error {
 // TODO
 //   - This has to been tested with any bad input, should be regarded as not stable.
 func (s *SamsungWebsocket) ChangeChannel(channel string) error {
+	return s.ChangeChannelContext(context.Background(), channel)
+}
+
+// ChangeChannelContext is ChangeChannel bound to the provided context.
+func (s *SamsungWebsocket) ChangeChannelContext(ctx context.Context, channel string) error {
 	split := strings.Split(channel, "")
 
 	for _, digit := range split {
-		err := s.SendKey(fmt.Sprintf("KEY_%s", digit), 1, "Click")
+		err := s.SendKeyContext(ctx, fmt.Sprintf("KEY_%s", digit), 1, "Click")
 		if err != nil {
 			return err
 		}
 	}
 
-	return s.SendKey(keys.NavigationEnter, 1, "Click")
+	return s.SendKeyContext(ctx, keys.NavigationEnter, 1, "Click")
 }
 
-// MoveCursor will command the TV to move the mouse cursor to a given X,Y position
-// over the provided duration.
-//
-// TODO
-//   - This requires to be tested, it has not been ran to close any applications yet.
-//   - This has to been tested with any bad input, should be regarded as not stable.
+// MoveCursor will command the TV to move the mouse cursor by the given X,Y
+// offset over the provided duration in milliseconds. See MovePointer and
+// MovePointerTo, which track the position of the cursor.
 func (s *SamsungWebsocket) MoveCursor(x, y, duration int) error {
+	return s.MoveCursorContext(context.Background(), x, y, duration)
+}
+
+// MoveCursorContext is MoveCursor bound to the provided context.
+func (s *SamsungWebsocket) MoveCursorContext(ctx context.Context, x, y, duration int) error {
 	log.Printf("Sending move Cursor to x: %d, y: %d for duration %d via ws api\n", x, y, duration)
 
 	var req = Request{
@@ -357,12 +669,12 @@ func (s *SamsungWebsocket) MoveCursor(x, y, duration int) error {
 			"Position": map[string]interface{}{
 				"x":    x,
 				"y":    y,
-				"Time": string(rune(duration)),
+				"Time": strconv.Itoa(duration),
 			},
 		},
 	}
 
-	return s.sendJSON(req)
+	return s.sendJSON(ctx, req)
 }
 
 // OpenBrowser will command the TV to open a given URL within the browser.
@@ -371,10 +683,48 @@ func (s *SamsungWebsocket) MoveCursor(x, y, duration int) error {
 //   - This requires to be tested, it has not been ran to close any applications yet.
 //   - This has to been tested with any bad input, should be regarded as not stable.
 func (s *SamsungWebsocket) OpenBrowser(url string) error {
+	return s.OpenBrowserContext(context.Background(), url)
+}
+
+// OpenBrowserContext is OpenBrowser bound to the provided context.
+func (s *SamsungWebsocket) OpenBrowserContext(ctx context.Context, url string) error {
 	log.Printf("opening browser to url: %s via ws api\n", url)
-	return s.RunApplication("org.tizen.browser", "NATIVE_LAUNCH", url)
+	return s.RunApplicationContext(ctx, "org.tizen.browser", "NATIVE_LAUNCH", url)
 }
 
+// Disconnect closes the current connection with the TV, stopping the background
+// reader and any attempt to reconnect. Subscriptions are kept and will receive
+// events once connected again, while calls awaiting a response, including
+// those queued while reconnecting, return ErrNotConnected.
 func (s *SamsungWebsocket) Disconnect() error {
-	return s.conn.Close()
+	s.stopReconnect()
+	err := s.closeConnection()
+
+	s.connMutex.Lock()
+	s.queue = nil
+	s.connMutex.Unlock()
+
+	s.failPending(ErrNotConnected)
+
+	s.setState(StateDisconnected, nil)
+
+	return err
+}
+
+// closeConnection closes the current connection without it being regarded
+// as dropped by the reader, failing the calls awaiting a response on it.
+func (s *SamsungWebsocket) closeConnection() error {
+	s.connMutex.Lock()
+	conn := s.conn
+	s.conn = nil
+	s.connMutex.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+
+	err := conn.Close()
+	s.failPending(ErrNotConnected)
+
+	return err
 }
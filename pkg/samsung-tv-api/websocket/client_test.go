@@ -0,0 +1,399 @@
+package websocket
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/keys"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/samsungtvtest"
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
+)
+
+// newTestServer starts a websocket server which sends the connect event and
+// then every frame provided through the returned channel.
+func newTestServer(t *testing.T) (*SamsungWebsocket, chan string) {
+	frames := make(chan string, 8)
+
+	srv := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		_ = websocket.Message.Send(ws, `{"event":"ms.channel.connect","data":{"token":"1234"}}`)
+
+		for frame := range frames {
+			_ = websocket.Message.Send(ws, frame)
+		}
+	}))
+
+	t.Cleanup(srv.Close)
+
+	client := &SamsungWebsocket{
+		BaseUrl: func(endpoint string) *url.URL {
+			return &url.URL{Scheme: "ws", Host: strings.TrimPrefix(srv.URL, "http://"), Path: endpoint}
+		},
+	}
+
+	return client, frames
+}
+
+func TestOpenConnectionDispatchesEvents(t *testing.T) {
+	client, frames := newTestServer(t)
+	defer close(frames)
+
+	all := client.Subscribe(AllEvents)
+	apps := client.Subscribe("ed.installedApp.get")
+
+	resp, err := client.OpenConnection()
+	assert.NoError(t, err)
+	assert.Equal(t, "1234", resp.Data.Token)
+	assert.NoError(t, client.WaitFor("ms.channel.connect"))
+
+	frames <- `{"event":"ed.installedApp.get","data":{"data":[{"appId":"111299001912","name":"YouTube"}]}}`
+
+	select {
+	case ev := <-apps:
+		var out ApplicationsResponse
+		assert.NoError(t, ev.Decode(&out))
+		assert.Equal(t, "YouTube", out.Data.Applications[0].Name)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for subscribed event")
+	}
+
+	var names []string
+	for len(all) > 0 {
+		if ev := <-all; ev.Event != ConnectionStateEvent {
+			names = append(names, ev.Event)
+		}
+	}
+	assert.Equal(t, []string{"ms.channel.connect", "ed.installedApp.get"}, names)
+
+	client.Unsubscribe(apps)
+	_, open := <-apps
+	assert.False(t, open)
+
+	assert.NoError(t, client.Disconnect())
+}
+
+func TestWaitForWithoutConnection(t *testing.T) {
+	client := &SamsungWebsocket{}
+	assert.ErrorIs(t, client.WaitFor("ms.channel.connect"), ErrNotConnected)
+}
+
+func TestWaitForContextDeadline(t *testing.T) {
+	client, frames := newTestServer(t)
+	defer close(frames)
+
+	_, err := client.OpenConnectionContext(context.Background())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, client.WaitForContext(ctx, "ms.remote.imeStart"), context.DeadlineExceeded)
+}
+
+func TestReconnectReplaysQueuedCommands(t *testing.T) {
+	var connections int32
+	received := make(chan string, 1)
+	drop := make(chan struct{})
+
+	srv := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		_ = websocket.Message.Send(ws, `{"event":"ms.channel.connect","data":{}}`)
+
+		if atomic.AddInt32(&connections, 1) == 1 {
+			<-drop
+			return
+		}
+
+		var msg string
+		if err := websocket.Message.Receive(ws, &msg); err == nil {
+			received <- msg
+		}
+	}))
+	defer srv.Close()
+
+	client := &SamsungWebsocket{
+		BaseUrl: func(endpoint string) *url.URL {
+			return &url.URL{Scheme: "ws", Host: strings.TrimPrefix(srv.URL, "http://"), Path: endpoint}
+		},
+	}
+	client.EnableReconnect(ReconnectPolicy{MinBackoff: 10 * time.Millisecond})
+
+	states := client.Subscribe(ConnectionStateEvent)
+
+	_, err := client.OpenConnection()
+	assert.NoError(t, err)
+
+	reconnecting := make(chan struct{})
+	go func() {
+		for ev := range states {
+			var change StateChange
+			_ = json.Unmarshal(ev.Data, &change)
+			if change.State == StateReconnecting {
+				close(reconnecting)
+				return
+			}
+		}
+	}()
+
+	close(drop)
+	<-reconnecting
+
+	assert.NoError(t, client.SendClick("KEY_HOME"))
+
+	select {
+	case msg := <-received:
+		assert.Contains(t, msg, "KEY_HOME")
+	case <-time.After(2 * time.Second):
+		t.Fatal("queued command was not replayed after reconnecting")
+	}
+
+	assert.Equal(t, StateConnected, client.State())
+	assert.NoError(t, client.Disconnect())
+	assert.Equal(t, StateDisconnected, client.State())
+}
+
+func TestDisconnectFailsQueuedCalls(t *testing.T) {
+	var connections int32
+	drop := make(chan struct{})
+
+	// the first connection is dropped and every reconnect is refused, so a
+	// call made meanwhile stays queued.
+	srv := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		if atomic.AddInt32(&connections, 1) == 1 {
+			_ = websocket.Message.Send(ws, `{"event":"ms.channel.connect","data":{}}`)
+			<-drop
+		}
+	}))
+	defer srv.Close()
+
+	client := &SamsungWebsocket{
+		BaseUrl: func(endpoint string) *url.URL {
+			return &url.URL{Scheme: "ws", Host: strings.TrimPrefix(srv.URL, "http://"), Path: endpoint}
+		},
+	}
+	client.EnableReconnect(ReconnectPolicy{MinBackoff: 10 * time.Millisecond})
+
+	_, err := client.OpenConnection()
+	assert.NoError(t, err)
+
+	close(drop)
+	assert.Eventually(t, func() bool {
+		return client.State() == StateReconnecting
+	}, time.Second, time.Millisecond)
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := client.GetApplicationsList()
+		result <- err
+	}()
+
+	assert.Eventually(t, func() bool {
+		client.subsMutex.Lock()
+		defer client.subsMutex.Unlock()
+		return len(client.pending) == 1
+	}, time.Second, time.Millisecond)
+
+	assert.NoError(t, client.Disconnect())
+
+	select {
+	case err := <-result:
+		assert.ErrorIs(t, err, ErrNotConnected)
+	case <-time.After(time.Second):
+		t.Fatal("queued call did not return after disconnecting")
+	}
+}
+
+func TestCallDefaultTimeout(t *testing.T) {
+	client, frames := newTestServer(t)
+	defer close(frames)
+
+	client.Timeout = 50 * time.Millisecond
+
+	_, err := client.OpenConnection()
+	assert.NoError(t, err)
+
+	_, err = client.GetApplicationsList()
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestConcurrentCallsReceiveTheirOwnResponses(t *testing.T) {
+	client, frames := newTestServer(t)
+	defer close(frames)
+
+	client.IDExtractor = func(ev Event) string {
+		var data struct {
+			RequestID string `json:"request_id"`
+		}
+		_ = json.Unmarshal(ev.Data, &data)
+		return data.RequestID
+	}
+
+	_, err := client.OpenConnection()
+	assert.NoError(t, err)
+
+	results := make(chan string, 3)
+	call := func(expect Expect) {
+		ev, err := client.Call(context.Background(), Request{Method: "ms.channel.emit"}, expect)
+		assert.NoError(t, err)
+		results <- expect.ID + ":" + string(ev.Data)
+	}
+
+	go call(Expect{Event: "d2d_service_message", ID: "a"})
+	go call(Expect{Event: "d2d_service_message", ID: "b"})
+	go call(Expect{Event: "ed.installedApp.get"})
+
+	assert.Eventually(t, func() bool {
+		client.subsMutex.Lock()
+		defer client.subsMutex.Unlock()
+		return len(client.pending) == 3
+	}, time.Second, time.Millisecond)
+
+	frames <- `{"event":"d2d_service_message","data":{"request_id":"b"}}`
+	frames <- `{"event":"ed.installedApp.get","data":{}}`
+	frames <- `{"event":"d2d_service_message","data":{"request_id":"a"}}`
+
+	var got []string
+	for i := 0; i < 3; i++ {
+		select {
+		case result := <-results:
+			got = append(got, result)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for call responses")
+		}
+	}
+
+	assert.ElementsMatch(t, []string{
+		`a:{"request_id":"a"}`,
+		`b:{"request_id":"b"}`,
+		`:{}`,
+	}, got)
+}
+
+func TestCallReturnsTVErrors(t *testing.T) {
+	client, frames := newTestServer(t)
+	defer close(frames)
+
+	_, err := client.OpenConnection()
+	assert.NoError(t, err)
+
+	go func() {
+		assert.Eventually(t, func() bool {
+			client.subsMutex.Lock()
+			defer client.subsMutex.Unlock()
+			return len(client.pending) == 1
+		}, time.Second, time.Millisecond)
+
+		frames <- `{"event":"ms.error","data":{"message":"unrecognized method value : ms.channel.emt"}}`
+	}()
+
+	_, err = client.GetApplicationsList()
+
+	var tvErr *tverrors.TVError
+	assert.ErrorAs(t, err, &tvErr)
+	assert.Equal(t, "ms.error", tvErr.Event)
+	assert.Equal(t, "unrecognized method value : ms.channel.emt", tvErr.Message)
+}
+
+func TestOpenConnectionUnauthorized(t *testing.T) {
+	srv := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		_ = websocket.Message.Send(ws, `{"event":"ms.channel.unauthorized"}`)
+	}))
+	defer srv.Close()
+
+	client := &SamsungWebsocket{
+		BaseUrl: func(endpoint string) *url.URL {
+			return &url.URL{Scheme: "ws", Host: strings.TrimPrefix(srv.URL, "http://"), Path: endpoint}
+		},
+	}
+
+	_, err := client.OpenConnection()
+	assert.ErrorIs(t, err, tverrors.ErrUnauthorized)
+	assert.Equal(t, StateDisconnected, client.State())
+}
+
+func TestFakeTVIssuesTokenAndRecordsKeys(t *testing.T) {
+	tv := samsungtvtest.NewServer()
+	defer tv.Close()
+
+	tv.AddApp(samsungtvtest.App{ID: "3201907018807", Name: "Netflix", AppType: 2})
+
+	client := &SamsungWebsocket{BaseUrl: tv.WebsocketBaseUrl}
+	defer client.Disconnect()
+
+	resp, err := client.OpenConnection()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.Data.Token)
+	assert.Equal(t, tv.Token(), resp.Data.Token)
+
+	assert.NoError(t, client.SendClick("KEY_HOME"))
+	assert.NoError(t, client.SendText(base64.StdEncoding.EncodeToString([]byte("hello"))))
+
+	apps, err := client.GetApplicationsList()
+	assert.NoError(t, err)
+	assert.Equal(t, "Netflix", apps.Data.Applications[0].Name)
+
+	assert.Equal(t, []samsungtvtest.KeyPress{{Cmd: "Click", Key: "KEY_HOME"}}, tv.Keys())
+	assert.Equal(t, []string{"hello"}, tv.Texts())
+}
+
+func TestFakeTVEmit(t *testing.T) {
+	tv := samsungtvtest.NewServer()
+	defer tv.Close()
+
+	client := &SamsungWebsocket{BaseUrl: tv.WebsocketBaseUrl}
+	defer client.Disconnect()
+
+	events := client.Subscribe("ms.channel.clientConnect")
+
+	_, err := client.OpenConnection()
+	assert.NoError(t, err)
+
+	tv.Emit("ms.channel.clientConnect", map[string]string{"id": "other"})
+
+	select {
+	case ev := <-events:
+		assert.JSONEq(t, `{"id":"other"}`, string(ev.Data))
+	case <-time.After(time.Second):
+		t.Fatal("event was not dispatched")
+	}
+}
+
+func TestFakeTVDeniesPairing(t *testing.T) {
+	tv := samsungtvtest.NewServer()
+	defer tv.Close()
+
+	tv.Pairing = samsungtvtest.PairingDeny
+
+	client := &SamsungWebsocket{BaseUrl: tv.WebsocketBaseUrl}
+
+	_, err := client.OpenConnection()
+	assert.ErrorIs(t, err, tverrors.ErrUnauthorized)
+	assert.Empty(t, tv.Token())
+}
+
+func TestSendKeyRejectsUnknownKeys(t *testing.T) {
+	tv := samsungtvtest.NewServer()
+	defer tv.Close()
+
+	client := &SamsungWebsocket{BaseUrl: tv.WebsocketBaseUrl}
+	defer client.Disconnect()
+
+	_, err := client.OpenConnection()
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, client.SendClick("KEY_VOLUMEUP"), keys.ErrUnknownKey)
+	assert.Empty(t, tv.Keys())
+
+	client.AllowUnknownKeys = true
+	assert.NoError(t, client.SendClick("KEY_VOLUMEUP"))
+	assert.Eventually(t, func() bool {
+		return len(tv.Keys()) == 1 && tv.Keys()[0].Key == "KEY_VOLUMEUP"
+	}, time.Second, 10*time.Millisecond)
+}
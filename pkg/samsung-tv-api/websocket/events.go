@@ -0,0 +1,147 @@
+package websocket
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/tverrors"
+)
+
+// AllEvents can be provided to Subscribe to receive every frame read from the
+// TV regardless of the event name.
+const AllEvents = "*"
+
+const (
+	// ErrorEvent is sent by the TV when it could not handle a request.
+	ErrorEvent = "ms.error"
+	// UnauthorizedEvent is sent by the TV when it rejects the client.
+	UnauthorizedEvent = "ms.channel.unauthorized"
+	// TimeoutEvent is sent by the TV when the user did not respond to the
+	// request to allow the connection.
+	TimeoutEvent = "ms.channel.timeOut"
+)
+
+// subscriberBuffer is the number of events buffered per subscriber before the
+// dispatcher starts dropping events for that subscriber.
+const subscriberBuffer = 16
+
+// Event is the envelope every frame read from the TV is decoded into before
+// being handed to any subscribers. Raw holds the complete frame so it can be
+// decoded into a more specific response type.
+type Event struct {
+	Event string          `json:"event"`
+	From  string          `json:"from,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+	Raw   []byte          `json:"-"`
+}
+
+// Decode will unmarshal the complete frame of the event into the provided value.
+func (e Event) Decode(val interface{}) error {
+	return json.Unmarshal(e.Raw, val)
+}
+
+// Err returns a *tverrors.TVError if the event reports a failure, otherwise nil.
+func (e Event) Err() error {
+	if e.Event != ErrorEvent && e.Event != UnauthorizedEvent && e.Event != TimeoutEvent {
+		return nil
+	}
+
+	var data struct {
+		Message string `json:"message"`
+	}
+
+	if err := json.Unmarshal(e.Data, &data); err != nil || data.Message == "" {
+		var message string
+		_ = json.Unmarshal(e.Data, &message)
+		data.Message = message
+	}
+
+	return &tverrors.TVError{Event: e.Event, Message: data.Message}
+}
+
+// decodeEvent converts a raw frame into an Event, keeping a copy of the raw
+// frame for later decoding.
+func decodeEvent(msg []byte) (Event, error) {
+	var ev Event
+
+	if err := json.Unmarshal(msg, &ev); err != nil {
+		return ev, err
+	}
+
+	ev.Raw = msg
+	return ev, nil
+}
+
+// Subscribe returns a channel which will receive every event with the given
+// name read from the TV, AllEvents can be used to receive every event.
+//
+// Subscriptions are held by the SamsungWebsocket rather than the underlying
+// connection, so they outlive calls to OpenConnection. Events are dropped for
+// a subscriber whose buffer is full rather than blocking the reader.
+func (s *SamsungWebsocket) Subscribe(event string) <-chan Event {
+	ch := make(chan Event, subscriberBuffer)
+
+	s.subsMutex.Lock()
+	defer s.subsMutex.Unlock()
+
+	if s.subscribers == nil {
+		s.subscribers = map[string][]chan Event{}
+	}
+
+	s.subscribers[event] = append(s.subscribers[event], ch)
+	return ch
+}
+
+// Unsubscribe removes a channel previously returned by Subscribe and closes it.
+func (s *SamsungWebsocket) Unsubscribe(sub <-chan Event) {
+	s.subsMutex.Lock()
+	defer s.subsMutex.Unlock()
+
+	for event, subs := range s.subscribers {
+		for i, ch := range subs {
+			if ch != sub {
+				continue
+			}
+
+			s.subscribers[event] = append(subs[:i], subs[i+1:]...)
+			close(ch)
+			return
+		}
+	}
+}
+
+// dispatch records the event as seen on the current connection, tracks the IME
+// state, resolves the pending call it answers and fans it out to every
+// subscriber of the event name and every AllEvents subscriber.
+func (s *SamsungWebsocket) dispatch(ev Event) {
+	s.subsMutex.Lock()
+	defer s.subsMutex.Unlock()
+
+	if s.seen == nil {
+		s.seen = map[string]Event{}
+	}
+
+	s.seen[ev.Event] = ev
+	s.trackIME(ev)
+	s.resolvePending(ev)
+
+	for _, name := range []string{ev.Event, AllEvents} {
+		for _, ch := range s.subscribers[name] {
+			select {
+			case ch <- ev:
+			default:
+				log.Printf("dropping event %s for slow subscriber\n", ev.Event)
+			}
+		}
+	}
+}
+
+// lastEvent returns the last event with the given name read on the current
+// connection, if any.
+func (s *SamsungWebsocket) lastEvent(event string) (Event, bool) {
+	s.subsMutex.Lock()
+	defer s.subsMutex.Unlock()
+
+	ev, ok := s.seen[event]
+	return ev, ok
+}
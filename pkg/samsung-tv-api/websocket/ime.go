@@ -0,0 +1,157 @@
+package websocket
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+)
+
+const (
+	// IMEStartEvent is sent by the TV when a text field is focused and the on
+	// screen keyboard is shown.
+	IMEStartEvent = "ms.remote.imeStart"
+	// IMEUpdateEvent is sent by the TV when the contents of the focused text
+	// field change, the data being the base64 encoded contents.
+	IMEUpdateEvent = "ms.remote.imeUpdate"
+	// IMEEndEvent is sent by the TV when the text field loses focus.
+	IMEEndEvent = "ms.remote.imeEnd"
+)
+
+// DefaultIMETimeout is how long TypeText waits for a text field to be focused
+// when IMETimeout is not set.
+const DefaultIMETimeout = 3 * time.Second
+
+// ErrNoTextField is returned by TypeText when no text field is focused on the
+// TV.
+var ErrNoTextField = errors.New("no text field is focused on the tv")
+
+// IMEState is the state of the text input on the TV, as reported by the IME
+// events read on the current connection. A text field focused before the
+// connection was opened is not known about.
+type IMEState struct {
+	// Active is true while a text field is focused.
+	Active bool
+	// Text holds the contents of the focused text field.
+	Text string
+}
+
+// IME returns the state of the text input on the TV.
+func (s *SamsungWebsocket) IME() IMEState {
+	s.subsMutex.Lock()
+	defer s.subsMutex.Unlock()
+
+	return s.ime
+}
+
+// trackIME updates the state of the text input from an IME event, callers
+// must hold subsMutex.
+func (s *SamsungWebsocket) trackIME(ev Event) {
+	switch ev.Event {
+	case IMEStartEvent:
+		s.ime = IMEState{Active: true}
+	case IMEUpdateEvent:
+		s.ime.Active = true
+		s.ime.Text = decodeIMEText(ev.Data)
+	case IMEEndEvent:
+		s.ime = IMEState{}
+	}
+}
+
+// decodeIMEText returns the contents of an imeUpdate event, which are sent as
+// a base64 encoded string.
+func decodeIMEText(data json.RawMessage) string {
+	var encoded string
+
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return ""
+	}
+
+	text, err := base64.StdEncoding.DecodeString(encoded)
+
+	if err != nil {
+		return encoded
+	}
+
+	return string(text)
+}
+
+// WaitForIME blocks until a text field is focused on the TV or the context is
+// done.
+func (s *SamsungWebsocket) WaitForIME(ctx context.Context) error {
+	events := s.Subscribe(IMEStartEvent)
+	defer s.Unsubscribe(events)
+
+	if s.IME().Active {
+		return nil
+	}
+
+	select {
+	case <-events:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TypeText types the plain text into the focused text field, waiting up to
+// IMETimeout for a field to be focused. ErrNoTextField is returned when no
+// field is focused in time.
+func (s *SamsungWebsocket) TypeText(ctx context.Context, text string) error {
+	return s.typeText(ctx, text, false)
+}
+
+// TypeTextAndSubmit is TypeText followed by SendInputEnd, submitting the text
+// and closing the on screen keyboard.
+func (s *SamsungWebsocket) TypeTextAndSubmit(ctx context.Context, text string) error {
+	return s.typeText(ctx, text, true)
+}
+
+func (s *SamsungWebsocket) typeText(ctx context.Context, text string, submit bool) error {
+	timeout := s.IMETimeout
+
+	if timeout <= 0 {
+		timeout = DefaultIMETimeout
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := s.WaitForIME(waitCtx); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		return ErrNoTextField
+	}
+
+	if err := s.SendTextContext(ctx, base64.StdEncoding.EncodeToString([]byte(text))); err != nil {
+		return err
+	}
+
+	if !submit {
+		return nil
+	}
+
+	return s.SendInputEndContext(ctx)
+}
+
+// SendInputEnd submits the text of the focused text field, closing the on
+// screen keyboard.
+func (s *SamsungWebsocket) SendInputEnd() error {
+	return s.SendInputEndContext(context.Background())
+}
+
+// SendInputEndContext is SendInputEnd bound to the provided context.
+func (s *SamsungWebsocket) SendInputEndContext(ctx context.Context) error {
+	log.Printf("Sending input end via ws api\n")
+
+	return s.sendJSON(ctx, Request{
+		Method: "ms.remote.control",
+		Params: map[string]interface{}{
+			"TypeOfRemote": "SendInputEnd",
+		},
+	})
+}
@@ -0,0 +1,68 @@
+package websocket
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/samsungtvtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func getFakeTVWebsocket(t *testing.T) (*SamsungWebsocket, *samsungtvtest.Server) {
+	tv := samsungtvtest.NewServer()
+	t.Cleanup(tv.Close)
+
+	client := &SamsungWebsocket{BaseUrl: tv.WebsocketBaseUrl, IMETimeout: 100 * time.Millisecond}
+	t.Cleanup(func() { _ = client.Disconnect() })
+
+	_, err := client.OpenConnection()
+	assert.NoError(t, err)
+
+	return client, tv
+}
+
+func TestIMETracksTextField(t *testing.T) {
+	client, tv := getFakeTVWebsocket(t)
+
+	assert.Equal(t, IMEState{}, client.IME())
+
+	tv.FocusTextField("star")
+
+	assert.Eventually(t, func() bool {
+		return client.IME() == IMEState{Active: true, Text: "star"}
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestTypeText(t *testing.T) {
+	client, tv := getFakeTVWebsocket(t)
+
+	tv.FocusTextField("")
+	assert.NoError(t, client.TypeText(context.Background(), "star wars"))
+
+	assert.Eventually(t, func() bool {
+		return client.IME().Text == "star wars"
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, samsungtvtest.TextField{Focused: true, Text: "star wars"}, tv.TextField())
+}
+
+func TestTypeTextAndSubmit(t *testing.T) {
+	client, tv := getFakeTVWebsocket(t)
+
+	tv.FocusTextField("")
+	assert.NoError(t, client.TypeTextAndSubmit(context.Background(), "dune"))
+
+	assert.Eventually(t, func() bool {
+		return !client.IME().Active
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, samsungtvtest.TextField{Text: "dune", Submitted: true}, tv.TextField())
+}
+
+func TestTypeTextWithoutTextField(t *testing.T) {
+	client, tv := getFakeTVWebsocket(t)
+
+	assert.ErrorIs(t, client.TypeText(context.Background(), "dune"), ErrNoTextField)
+	assert.Empty(t, tv.Texts())
+}
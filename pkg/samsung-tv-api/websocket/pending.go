@@ -0,0 +1,177 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// DefaultTimeout bounds calls whose context carries no deadline.
+const DefaultTimeout = 10 * time.Second
+
+// Expect describes the response awaited by Call. When ID is set the response is
+// matched on the id extracted from each event, otherwise the oldest pending
+// call expecting the event name receives the next event with that name.
+type Expect struct {
+	Event string
+	ID    string
+}
+
+// pendingCall is a request registered by Call awaiting its response.
+type pendingCall struct {
+	expect Expect
+	ch     chan Event
+	// failed receives the error of a call given up on before it was
+	// answered, such as when disconnecting.
+	failed chan error
+}
+
+// eventID returns the id of the event used to correlate responses, using the
+// IDExtractor when configured and the top level "id" of the frame otherwise.
+func (s *SamsungWebsocket) eventID(ev Event) string {
+	if s.IDExtractor != nil {
+		return s.IDExtractor(ev)
+	}
+
+	var frame struct {
+		ID json.RawMessage `json:"id"`
+	}
+
+	if err := json.Unmarshal(ev.Raw, &frame); err != nil || len(frame.ID) == 0 {
+		return ""
+	}
+
+	var id string
+	if err := json.Unmarshal(frame.ID, &id); err == nil {
+		return id
+	}
+
+	return string(frame.ID)
+}
+
+// Call sends the request to the TV and waits for the response described by the
+// expectation, allowing many requests to be in flight at once from different
+// goroutines without stealing each other's responses.
+//
+// An ms.error event answers the pending call with a matching id, or the oldest
+// pending call otherwise, while ms.channel.unauthorized answers every pending
+// call. Both are returned as a *tverrors.TVError.
+//
+// The call is bound to the context, falling back to the Timeout of the client
+// when the context has no deadline. Calls still pending when the connection is
+// closed or replaced return ErrNotConnected.
+func (s *SamsungWebsocket) Call(ctx context.Context, req interface{}, expect Expect) (Event, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		timeout := s.Timeout
+
+		if timeout == 0 {
+			timeout = DefaultTimeout
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	call := &pendingCall{expect: expect, ch: make(chan Event, 1), failed: make(chan error, 1)}
+
+	s.subsMutex.Lock()
+	s.pending = append(s.pending, call)
+	s.subsMutex.Unlock()
+
+	defer s.removePending(call)
+
+	if err := s.sendJSON(ctx, req); err != nil {
+		return Event{}, err
+	}
+
+	done, conn := s.closed()
+
+	if conn == nil {
+		// the request was queued while reconnecting, wait for the next
+		// connection to answer it.
+		done = nil
+	}
+
+	select {
+	case ev := <-call.ch:
+		return ev, ev.Err()
+	case err := <-call.failed:
+		return Event{}, err
+	case <-done:
+		return Event{}, s.connectionError()
+	case <-ctx.Done():
+		return Event{}, ctx.Err()
+	}
+}
+
+// removePending removes the call from the pending calls if still present.
+func (s *SamsungWebsocket) removePending(call *pendingCall) {
+	s.subsMutex.Lock()
+	defer s.subsMutex.Unlock()
+
+	for i, pending := range s.pending {
+		if pending == call {
+			s.pending = append(s.pending[:i], s.pending[i+1:]...)
+			return
+		}
+	}
+}
+
+// failPending answers every pending call with the error.
+func (s *SamsungWebsocket) failPending(err error) {
+	s.subsMutex.Lock()
+	defer s.subsMutex.Unlock()
+
+	for _, call := range s.pending {
+		call.failed <- err
+	}
+
+	s.pending = nil
+}
+
+// resolvePending hands the event to the pending call it answers, if any, and
+// must be called while holding subsMutex.
+func (s *SamsungWebsocket) resolvePending(ev Event) {
+	if len(s.pending) == 0 {
+		return
+	}
+
+	if ev.Event == UnauthorizedEvent {
+		for _, call := range s.pending {
+			call.ch <- ev
+		}
+
+		s.pending = nil
+		return
+	}
+
+	id := s.eventID(ev)
+	match := -1
+
+	for i, pending := range s.pending {
+		if pending.expect.ID != "" {
+			if pending.expect.ID == id {
+				match = i
+				break
+			}
+			continue
+		}
+
+		if match == -1 && (pending.expect.Event == ev.Event || ev.Event == ErrorEvent) {
+			match = i
+
+			if id == "" {
+				break
+			}
+		}
+	}
+
+	if match == -1 {
+		return
+	}
+
+	call := s.pending[match]
+	s.pending = append(s.pending[:match], s.pending[match+1:]...)
+	call.ch <- ev
+}
@@ -0,0 +1,270 @@
+package websocket
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+)
+
+// Point is a position on the screen of the TV in pixels, the origin being the
+// top left corner.
+type Point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// PointerPolicy configures the pointer, used by apps such as the browser. Zero
+// values are replaced with the defaults below.
+type PointerPolicy struct {
+	// Width and Height are the size of the screen the pointer moves within.
+	// Defaults to 1920x1080.
+	Width  int
+	Height int
+	// StepInterval is the delay between the moves a smoothed path is split
+	// into. Defaults to 16ms.
+	StepInterval time.Duration
+}
+
+func (p PointerPolicy) withDefaults() PointerPolicy {
+	if p.Width <= 0 {
+		p.Width = 1920
+	}
+
+	if p.Height <= 0 {
+		p.Height = 1080
+	}
+
+	if p.StepInterval <= 0 {
+		p.StepInterval = 16 * time.Millisecond
+	}
+
+	return p
+}
+
+// Pointer returns the position of the pointer and whether it is known, which
+// it is once the pointer was moved to an absolute position.
+func (s *SamsungWebsocket) Pointer() (Point, bool) {
+	s.pointerMutex.Lock()
+	defer s.pointerMutex.Unlock()
+
+	return s.pointer, s.pointerKnown
+}
+
+// HomePointer moves the pointer to the top left corner of the screen, making
+// its position known. The TV only accepts relative moves, so the pointer is
+// moved further than the size of the screen.
+func (s *SamsungWebsocket) HomePointer(ctx context.Context) error {
+	policy := s.PointerPolicy.withDefaults()
+
+	log.Printf("Sending pointer home via ws api\n")
+
+	if err := s.sendMouse(ctx, "Move", -2*policy.Width, -2*policy.Height); err != nil {
+		return err
+	}
+
+	s.pointerMutex.Lock()
+	s.pointer, s.pointerKnown = Point{}, true
+	s.pointerMutex.Unlock()
+
+	return nil
+}
+
+// MovePointer moves the pointer by the given offset.
+func (s *SamsungWebsocket) MovePointer(ctx context.Context, dx, dy int) error {
+	log.Printf("Sending pointer move by x: %d, y: %d via ws api\n", dx, dy)
+	return s.movePointer(ctx, dx, dy)
+}
+
+// MovePointerTo moves the pointer to the position on the screen, homing the
+// pointer first when its position is not known.
+func (s *SamsungWebsocket) MovePointerTo(ctx context.Context, x, y int) error {
+	log.Printf("Sending pointer move to x: %d, y: %d via ws api\n", x, y)
+
+	from, err := s.knownPointer(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	target := s.clamp(Point{X: x, Y: y})
+
+	return s.movePointer(ctx, target.X-from.X, target.Y-from.Y)
+}
+
+// MovePointerAlong moves the pointer smoothly through every point of the path
+// over the duration, as a finger on a touchpad would. The path is interpolated
+// with a Catmull-Rom spline starting from the current position of the pointer,
+// which is homed first when not known.
+func (s *SamsungWebsocket) MovePointerAlong(ctx context.Context, path []Point, duration time.Duration) error {
+	if len(path) == 0 {
+		return nil
+	}
+
+	log.Printf("Sending pointer move along %d points over %s via ws api\n", len(path), duration)
+
+	from, err := s.knownPointer(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	policy := s.PointerPolicy.withDefaults()
+	points := []Point{from}
+
+	for _, p := range path {
+		points = append(points, s.clamp(p))
+	}
+
+	steps := int(duration / policy.StepInterval)
+	current := from
+
+	for _, target := range interpolate(points, steps) {
+		// the spline may overshoot the screen, which the pointer cannot leave.
+		if target = s.clamp(target); target == current {
+			continue
+		}
+
+		if err := s.movePointer(ctx, target.X-current.X, target.Y-current.Y); err != nil {
+			return err
+		}
+
+		current = target
+
+		if err := sleep(ctx, policy.StepInterval); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LeftClick clicks at the current position of the pointer.
+func (s *SamsungWebsocket) LeftClick(ctx context.Context) error {
+	log.Printf("Sending pointer left click via ws api\n")
+
+	return s.sendJSON(ctx, Request{
+		Method: "ms.remote.control",
+		Params: map[string]interface{}{
+			"Cmd":          "LeftClick",
+			"TypeOfRemote": "ProcessMouseDevice",
+		},
+	})
+}
+
+// Scroll scrolls the content under the pointer by the given offset, positive
+// values scrolling down and right.
+func (s *SamsungWebsocket) Scroll(ctx context.Context, dx, dy int) error {
+	log.Printf("Sending pointer scroll by x: %d, y: %d via ws api\n", dx, dy)
+	return s.sendMouse(ctx, "Scroll", dx, dy)
+}
+
+// knownPointer returns the position of the pointer, homing it when unknown.
+func (s *SamsungWebsocket) knownPointer(ctx context.Context) (Point, error) {
+	if p, ok := s.Pointer(); ok {
+		return p, nil
+	}
+
+	if err := s.HomePointer(ctx); err != nil {
+		return Point{}, err
+	}
+
+	return Point{}, nil
+}
+
+// movePointer moves the pointer by the offset, keeping track of its position
+// when known.
+func (s *SamsungWebsocket) movePointer(ctx context.Context, dx, dy int) error {
+	if err := s.sendMouse(ctx, "Move", dx, dy); err != nil {
+		return err
+	}
+
+	s.pointerMutex.Lock()
+	defer s.pointerMutex.Unlock()
+
+	if s.pointerKnown {
+		s.pointer = s.clamp(Point{X: s.pointer.X + dx, Y: s.pointer.Y + dy})
+	}
+
+	return nil
+}
+
+func (s *SamsungWebsocket) sendMouse(ctx context.Context, cmd string, x, y int) error {
+	return s.sendJSON(ctx, Request{
+		Method: "ms.remote.control",
+		Params: map[string]interface{}{
+			"Cmd":          cmd,
+			"TypeOfRemote": "ProcessMouseDevice",
+			"Position": map[string]interface{}{
+				"x":    x,
+				"y":    y,
+				"Time": "0",
+			},
+		},
+	})
+}
+
+// clamp keeps the point within the screen.
+func (s *SamsungWebsocket) clamp(p Point) Point {
+	policy := s.PointerPolicy.withDefaults()
+
+	return Point{
+		X: min(max(p.X, 0), policy.Width-1),
+		Y: min(max(p.Y, 0), policy.Height-1),
+	}
+}
+
+// interpolate returns the points along a Catmull-Rom spline through every
+// point, excluding the first, split into roughly the number of steps with the
+// steps of each segment in proportion to its length. Every segment ends exactly
+// on its point.
+func interpolate(points []Point, steps int) []Point {
+	segments := len(points) - 1
+
+	if segments < 1 {
+		return nil
+	}
+
+	total := 0.0
+
+	for i := 0; i < segments; i++ {
+		total += distance(points[i], points[i+1])
+	}
+
+	var out []Point
+
+	for i := 0; i < segments; i++ {
+		p0, p1, p2, p3 := points[max(i-1, 0)], points[i], points[i+1], points[min(i+2, segments)]
+
+		n := 1
+
+		if total > 0 {
+			n = max(1, int(math.Round(float64(steps)*distance(p1, p2)/total)))
+		}
+
+		for j := 1; j <= n; j++ {
+			out = append(out, catmullRom(p0, p1, p2, p3, float64(j)/float64(n)))
+		}
+	}
+
+	return out
+}
+
+// catmullRom returns the point at t, between 0 and 1, on the segment of the
+// spline from p1 to p2.
+func catmullRom(p0, p1, p2, p3 Point, t float64) Point {
+	at := func(a, b, c, d int) int {
+		v := 0.5 * (2*float64(b) +
+			(-float64(a)+float64(c))*t +
+			(2*float64(a)-5*float64(b)+4*float64(c)-float64(d))*t*t +
+			(-float64(a)+3*float64(b)-3*float64(c)+float64(d))*t*t*t)
+
+		return int(math.Round(v))
+	}
+
+	return Point{X: at(p0.X, p1.X, p2.X, p3.X), Y: at(p0.Y, p1.Y, p2.Y, p3.Y)}
+}
+
+func distance(a, b Point) float64 {
+	return math.Hypot(float64(b.X-a.X), float64(b.Y-a.Y))
+}
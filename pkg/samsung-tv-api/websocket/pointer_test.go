@@ -0,0 +1,87 @@
+package websocket
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/samsungtvtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// waitForMouse waits until the fake TV received the number of pointer commands.
+func waitForMouse(t *testing.T, tv *samsungtvtest.Server, count int) {
+	assert.Eventually(t, func() bool {
+		return len(tv.Mouse()) == count
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestMovePointerTo(t *testing.T) {
+	client, tv := getFakeTVWebsocket(t)
+	ctx := context.Background()
+
+	_, known := client.Pointer()
+	assert.False(t, known)
+
+	assert.NoError(t, client.MovePointerTo(ctx, 500, 300))
+	assert.NoError(t, client.MovePointer(ctx, -100, 5000))
+	assert.NoError(t, client.LeftClick(ctx))
+
+	p, known := client.Pointer()
+	assert.True(t, known)
+	assert.Equal(t, Point{X: 400, Y: 1079}, p)
+
+	waitForMouse(t, tv, 4)
+
+	assert.Equal(t, []samsungtvtest.MouseEvent{
+		{Cmd: "Move", X: -3840, Y: -2160},
+		{Cmd: "Move", X: 500, Y: 300},
+		{Cmd: "Move", X: -100, Y: 5000},
+		{Cmd: "LeftClick"},
+	}, tv.Mouse())
+
+	x, y := tv.Cursor()
+	assert.Equal(t, Point{X: 400, Y: 1079}, Point{X: x, Y: y})
+}
+
+func TestMovePointerAlong(t *testing.T) {
+	client, tv := getFakeTVWebsocket(t)
+	client.PointerPolicy.StepInterval = time.Millisecond
+
+	path := []Point{{X: 200, Y: 100}, {X: 400, Y: 400}, {X: 100, Y: 600}}
+	assert.NoError(t, client.MovePointerAlong(context.Background(), path, 50*time.Millisecond))
+
+	p, _ := client.Pointer()
+	assert.Equal(t, Point{X: 100, Y: 600}, p)
+
+	assert.Eventually(t, func() bool {
+		x, y := tv.Cursor()
+		return x == 100 && y == 600
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Greater(t, len(tv.Mouse()), len(path)+1)
+}
+
+func TestInterpolate(t *testing.T) {
+	points := interpolate([]Point{{0, 0}, {100, 0}, {100, 100}}, 10)
+
+	assert.Len(t, points, 10)
+	assert.Equal(t, Point{X: 100, Y: 0}, points[4])
+	assert.Equal(t, Point{X: 100, Y: 100}, points[9])
+
+	assert.Equal(t, []Point{{X: 10, Y: 10}}, interpolate([]Point{{0, 0}, {10, 10}}, 0))
+	assert.Nil(t, interpolate([]Point{{0, 0}}, 10))
+}
+
+func TestScrollAndMoveCursor(t *testing.T) {
+	client, tv := getFakeTVWebsocket(t)
+
+	assert.NoError(t, client.Scroll(context.Background(), 0, 120))
+	assert.NoError(t, client.MoveCursor(10, 20, 250))
+
+	waitForMouse(t, tv, 2)
+	assert.Equal(t, []samsungtvtest.MouseEvent{{Cmd: "Scroll", Y: 120}, {Cmd: "Move", X: 10, Y: 20}}, tv.Mouse())
+
+	requests := tv.Requests()
+	assert.Contains(t, string(requests[len(requests)-1]), `"Time":"250"`)
+}
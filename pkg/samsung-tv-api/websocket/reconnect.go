@@ -0,0 +1,234 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+)
+
+// ConnectionState describes the state of the connection with the TV.
+type ConnectionState string
+
+const (
+	StateDisconnected ConnectionState = "disconnected"
+	StateConnecting   ConnectionState = "connecting"
+	StateConnected    ConnectionState = "connected"
+	StateReconnecting ConnectionState = "reconnecting"
+)
+
+// ConnectionStateEvent is the name of the event dispatched to subscribers every
+// time the connection state changes, the data of the event is a StateChange.
+const ConnectionStateEvent = "connection.state"
+
+// ErrQueueFull is returned when a command is sent while reconnecting and the
+// queue of commands waiting to be replayed is already full.
+var ErrQueueFull = errors.New("reconnect command queue is full")
+
+// StateChange is the data of a ConnectionStateEvent.
+type StateChange struct {
+	State ConnectionState `json:"state"`
+	// Error is the reason the connection was dropped, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// ReconnectPolicy configures how a dropped connection is re-established. Zero
+// values are replaced with the defaults below.
+type ReconnectPolicy struct {
+	// MinBackoff is the delay before the first reconnect attempt, doubling
+	// after every failed attempt. Defaults to 500ms.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between attempts. Defaults to 30s.
+	MaxBackoff time.Duration
+	// AttemptTimeout bounds every single reconnect attempt. Defaults to 10s.
+	AttemptTimeout time.Duration
+	// MaxQueued is the number of commands kept while reconnecting, which are
+	// replayed once connected again. Defaults to 32.
+	MaxQueued int
+}
+
+func (p ReconnectPolicy) withDefaults() ReconnectPolicy {
+	if p.MinBackoff <= 0 {
+		p.MinBackoff = 500 * time.Millisecond
+	}
+
+	if p.MaxBackoff < p.MinBackoff {
+		p.MaxBackoff = 30 * time.Second
+	}
+
+	if p.AttemptTimeout <= 0 {
+		p.AttemptTimeout = 10 * time.Second
+	}
+
+	if p.MaxQueued <= 0 {
+		p.MaxQueued = 32
+	}
+
+	return p
+}
+
+// EnableReconnect opts in to automatically re-establishing the connection when
+// it is dropped, for example when the TV goes into standby. Reconnecting uses
+// the same BaseUrl and so the stored token, subscriptions are kept and commands
+// sent while reconnecting are queued and replayed in order.
+//
+// Reconnecting stops when Disconnect is called.
+func (s *SamsungWebsocket) EnableReconnect(policy ReconnectPolicy) {
+	policy = policy.withDefaults()
+
+	s.connMutex.Lock()
+	defer s.connMutex.Unlock()
+
+	s.reconnect = &policy
+}
+
+// State returns the current state of the connection with the TV.
+func (s *SamsungWebsocket) State() ConnectionState {
+	s.connMutex.Lock()
+	defer s.connMutex.Unlock()
+
+	if s.state == "" {
+		return StateDisconnected
+	}
+
+	return s.state
+}
+
+// setState updates the connection state, dispatching a ConnectionStateEvent to
+// subscribers if it changed.
+func (s *SamsungWebsocket) setState(state ConnectionState, cause error) {
+	s.connMutex.Lock()
+	changed := s.state != state
+	s.state = state
+	s.connMutex.Unlock()
+
+	if !changed {
+		return
+	}
+
+	change := StateChange{State: state}
+
+	if cause != nil {
+		change.Error = cause.Error()
+	}
+
+	data, _ := json.Marshal(change)
+	raw, _ := json.Marshal(Event{Event: ConnectionStateEvent, Data: data})
+
+	s.dispatch(Event{Event: ConnectionStateEvent, Data: data, Raw: raw})
+}
+
+// stopReconnect cancels any reconnect currently in progress.
+func (s *SamsungWebsocket) stopReconnect() {
+	s.connMutex.Lock()
+	cancel := s.reconnectCancel
+	s.reconnectCancel = nil
+	s.connMutex.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// connectionDropped is called by the reader when the current connection closed
+// without Disconnect being called, starting to reconnect if enabled.
+func (s *SamsungWebsocket) connectionDropped(cause error) {
+	s.connMutex.Lock()
+	policy := s.reconnect
+
+	if policy == nil {
+		s.connMutex.Unlock()
+		s.setState(StateDisconnected, cause)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.reconnectCancel = cancel
+	s.connMutex.Unlock()
+
+	s.setState(StateReconnecting, cause)
+
+	go s.supervise(ctx, *policy)
+}
+
+// supervise attempts to open a new connection with exponential backoff until
+// it succeeds or the context is cancelled.
+func (s *SamsungWebsocket) supervise(ctx context.Context, policy ReconnectPolicy) {
+	backoff := policy.MinBackoff
+
+	for {
+		if err := sleep(ctx, backoff); err != nil {
+			return
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, policy.AttemptTimeout)
+		_, err := s.open(attemptCtx)
+		cancel()
+
+		if err == nil {
+			return
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Printf("reconnect failed, retrying in %s: %v\n", backoff, err)
+
+		backoff *= 2
+
+		if backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+// enqueue keeps a command sent without a connection so it can be replayed once
+// reconnected, returning ErrNotConnected when reconnecting is not enabled or
+// the connection was closed through Disconnect.
+func (s *SamsungWebsocket) enqueue(msg []byte) error {
+	s.connMutex.Lock()
+	defer s.connMutex.Unlock()
+
+	if s.reconnect == nil || (s.state != StateConnected && s.state != StateReconnecting) {
+		return ErrNotConnected
+	}
+
+	if len(s.queue) >= s.reconnect.MaxQueued {
+		return ErrQueueFull
+	}
+
+	log.Println("queueing command until reconnected")
+	s.queue = append(s.queue, msg)
+
+	return nil
+}
+
+// flushQueue replays the commands queued while reconnecting in order, keeping
+// any commands which could not be written for the next connection.
+func (s *SamsungWebsocket) flushQueue(ctx context.Context) {
+	s.connMutex.Lock()
+	queue := s.queue
+	s.queue = nil
+	conn := s.conn
+	s.connMutex.Unlock()
+
+	if len(queue) == 0 || conn == nil {
+		return
+	}
+
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	for i, msg := range queue {
+		if _, err := conn.Write(msg); err != nil {
+			log.Println("unable to replay queued command:", contextError(ctx, err))
+
+			s.connMutex.Lock()
+			s.queue = append(queue[i:], s.queue...)
+			s.connMutex.Unlock()
+			return
+		}
+	}
+}
@@ -36,7 +36,8 @@ func (c *SonosClient) GetToken() string {
 	return ""
 }
 
-func (c *SonosClient) Init() {
+func (c *SonosClient) Init() error {
+	return nil
 }
 
 func (c *SonosClient) PowerOff() error {
@@ -0,0 +1,448 @@
+package upnp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TransportState is the state of the AVTransport service.
+type TransportState string
+
+const (
+	StateStopped        TransportState = "STOPPED"
+	StatePlaying        TransportState = "PLAYING"
+	StatePaused         TransportState = "PAUSED_PLAYBACK"
+	StateTransitioning  TransportState = "TRANSITIONING"
+	StateNoMediaPresent TransportState = "NO_MEDIA_PRESENT"
+)
+
+// PlayMode is the order the tracks of the media are played in.
+type PlayMode string
+
+const (
+	PlayModeNormal    PlayMode = "NORMAL"
+	PlayModeShuffle   PlayMode = "SHUFFLE"
+	PlayModeRepeatOne PlayMode = "REPEAT_ONE"
+	PlayModeRepeatAll PlayMode = "REPEAT_ALL"
+	PlayModeRandom    PlayMode = "RANDOM"
+	PlayModeDirect    PlayMode = "DIRECT_1"
+	PlayModeIntro     PlayMode = "INTRO"
+)
+
+// SeekMode is the unit of the target of Seek.
+type SeekMode string
+
+const (
+	// SeekRelTime seeks to a time within the current track, see SeekTo.
+	SeekRelTime SeekMode = "REL_TIME"
+	// SeekAbsTime seeks to a time within the whole media.
+	SeekAbsTime SeekMode = "ABS_TIME"
+	// SeekTrackNr seeks to the start of a track, see SeekTrack.
+	SeekTrackNr SeekMode = "TRACK_NR"
+)
+
+// notImplemented is reported by devices for values they do not support.
+const notImplemented = "NOT_IMPLEMENTED"
+
+// TransportInfo is the response of GetTransportInfo.
+type TransportInfo struct {
+	State TransportState
+	// Status is OK, or ERROR_OCCURRED when the device failed to play.
+	Status string
+	Speed  string
+}
+
+// PositionInfo is the response of GetPositionInfo.
+type PositionInfo struct {
+	Track         int
+	TrackDuration time.Duration
+	// TrackMetaData is the DIDL-Lite document describing the track.
+	TrackMetaData string
+	TrackURI      string
+	RelTime       time.Duration
+	AbsTime       time.Duration
+	RelCount      int
+	AbsCount      int
+}
+
+// MediaInfo is the response of GetMediaInfo.
+type MediaInfo struct {
+	NrTracks           int
+	MediaDuration      time.Duration
+	CurrentURI         string
+	CurrentURIMetaData string
+	NextURI            string
+	NextURIMetaData    string
+	PlayMedium         string
+	RecordMedium       string
+	WriteStatus        string
+}
+
+// TransportSettings is the response of GetTransportSettings.
+type TransportSettings struct {
+	PlayMode       PlayMode
+	RecQualityMode string
+}
+
+// DeviceCapabilities is the response of GetDeviceCapabilities, each holding
+// the supported values such as NETWORK.
+type DeviceCapabilities struct {
+	PlayMedia       []string
+	RecMedia        []string
+	RecQualityModes []string
+}
+
+// AVTransport is a typed client of the AVTransport service, which plays media
+// from a url on the TV. It is returned by UpnpClient.AVTransport.
+type AVTransport struct {
+	client *UpnpClient
+}
+
+// AVTransport returns a typed client of the AVTransport service.
+func (s *UpnpClient) AVTransport() AVTransport {
+	return AVTransport{client: s}
+}
+
+// SetAVTransportURI sets the media played, described by the optional
+// DIDL-Lite metadata. The media is not played until Play is called.
+func (t AVTransport) SetAVTransportURI(ctx context.Context, uri, metadata string) error {
+	log.Printf("set the media of the tv to %s via soap api\n", uri)
+
+	args := struct {
+		InstanceID
+		CurrentURI         string
+		CurrentURIMetaData string
+	}{CurrentURI: uri, CurrentURIMetaData: metadata}
+
+	return t.call(ctx, "SetAVTransportURI", args, nil)
+}
+
+// SetNextAVTransportURI sets the media played once the current media ends,
+// allowing gapless playback.
+func (t AVTransport) SetNextAVTransportURI(ctx context.Context, uri, metadata string) error {
+	log.Printf("set the next media of the tv to %s via soap api\n", uri)
+
+	args := struct {
+		InstanceID
+		NextURI         string
+		NextURIMetaData string
+	}{NextURI: uri, NextURIMetaData: metadata}
+
+	return t.call(ctx, "SetNextAVTransportURI", args, nil)
+}
+
+// Play plays the current media at normal speed.
+func (t AVTransport) Play(ctx context.Context) error {
+	args := struct {
+		InstanceID
+		Speed string
+	}{Speed: "1"}
+
+	return t.call(ctx, "Play", args, nil)
+}
+
+// Pause pauses the current media.
+func (t AVTransport) Pause(ctx context.Context) error {
+	return t.call(ctx, "Pause", InstanceID{}, nil)
+}
+
+// Stop stops the current media.
+func (t AVTransport) Stop(ctx context.Context) error {
+	return t.call(ctx, "Stop", InstanceID{}, nil)
+}
+
+// Next plays the next track of the current media.
+func (t AVTransport) Next(ctx context.Context) error {
+	return t.call(ctx, "Next", InstanceID{}, nil)
+}
+
+// Previous plays the previous track of the current media.
+func (t AVTransport) Previous(ctx context.Context) error {
+	return t.call(ctx, "Previous", InstanceID{}, nil)
+}
+
+// Seek seeks to the target, formatted as H:MM:SS for the time modes and as
+// the track number for SeekTrackNr.
+func (t AVTransport) Seek(ctx context.Context, mode SeekMode, target string) error {
+	log.Printf("seek the media of the tv to %s %s via soap api\n", mode, target)
+
+	args := struct {
+		InstanceID
+		Unit   SeekMode
+		Target string
+	}{Unit: mode, Target: target}
+
+	return t.call(ctx, "Seek", args, nil)
+}
+
+// SeekTo seeks to the position within the current track.
+func (t AVTransport) SeekTo(ctx context.Context, position time.Duration) error {
+	return t.Seek(ctx, SeekRelTime, FormatDuration(position))
+}
+
+// SeekTrack seeks to the start of the track, numbered from 1.
+func (t AVTransport) SeekTrack(ctx context.Context, track int) error {
+	return t.Seek(ctx, SeekTrackNr, strconv.Itoa(track))
+}
+
+// SetPlayMode sets the order the tracks of the media are played in.
+func (t AVTransport) SetPlayMode(ctx context.Context, mode PlayMode) error {
+	args := struct {
+		InstanceID
+		NewPlayMode PlayMode
+	}{NewPlayMode: mode}
+
+	return t.call(ctx, "SetPlayMode", args, nil)
+}
+
+// GetTransportInfo returns whether media is playing.
+func (t AVTransport) GetTransportInfo(ctx context.Context) (TransportInfo, error) {
+	var resp struct {
+		CurrentTransportState  string
+		CurrentTransportStatus string
+		CurrentSpeed           string
+	}
+
+	if err := t.call(ctx, "GetTransportInfo", InstanceID{}, &resp); err != nil {
+		return TransportInfo{}, err
+	}
+
+	return TransportInfo{
+		State:  TransportState(resp.CurrentTransportState),
+		Status: resp.CurrentTransportStatus,
+		Speed:  resp.CurrentSpeed,
+	}, nil
+}
+
+// GetPositionInfo returns the current track and the position within it.
+func (t AVTransport) GetPositionInfo(ctx context.Context) (PositionInfo, error) {
+	var resp struct {
+		Track         string
+		TrackDuration string
+		TrackMetaData string
+		TrackURI      string
+		RelTime       string
+		AbsTime       string
+		RelCount      string
+		AbsCount      string
+	}
+
+	if err := t.call(ctx, "GetPositionInfo", InstanceID{}, &resp); err != nil {
+		return PositionInfo{}, err
+	}
+
+	var p parser
+
+	info := PositionInfo{
+		Track:         p.int("Track", resp.Track),
+		TrackDuration: p.duration("TrackDuration", resp.TrackDuration),
+		TrackMetaData: resp.TrackMetaData,
+		TrackURI:      resp.TrackURI,
+		RelTime:       p.duration("RelTime", resp.RelTime),
+		AbsTime:       p.duration("AbsTime", resp.AbsTime),
+		RelCount:      p.int("RelCount", resp.RelCount),
+		AbsCount:      p.int("AbsCount", resp.AbsCount),
+	}
+
+	return info, p.err
+}
+
+// GetMediaInfo returns the current and next media.
+func (t AVTransport) GetMediaInfo(ctx context.Context) (MediaInfo, error) {
+	var resp struct {
+		NrTracks           string
+		MediaDuration      string
+		CurrentURI         string
+		CurrentURIMetaData string
+		NextURI            string
+		NextURIMetaData    string
+		PlayMedium         string
+		RecordMedium       string
+		WriteStatus        string
+	}
+
+	if err := t.call(ctx, "GetMediaInfo", InstanceID{}, &resp); err != nil {
+		return MediaInfo{}, err
+	}
+
+	var p parser
+
+	info := MediaInfo{
+		NrTracks:           p.int("NrTracks", resp.NrTracks),
+		MediaDuration:      p.duration("MediaDuration", resp.MediaDuration),
+		CurrentURI:         resp.CurrentURI,
+		CurrentURIMetaData: resp.CurrentURIMetaData,
+		NextURI:            resp.NextURI,
+		NextURIMetaData:    resp.NextURIMetaData,
+		PlayMedium:         resp.PlayMedium,
+		RecordMedium:       resp.RecordMedium,
+		WriteStatus:        resp.WriteStatus,
+	}
+
+	return info, p.err
+}
+
+// GetTransportSettings returns the play mode.
+func (t AVTransport) GetTransportSettings(ctx context.Context) (TransportSettings, error) {
+	var resp struct {
+		PlayMode       string
+		RecQualityMode string
+	}
+
+	if err := t.call(ctx, "GetTransportSettings", InstanceID{}, &resp); err != nil {
+		return TransportSettings{}, err
+	}
+
+	return TransportSettings{PlayMode: PlayMode(resp.PlayMode), RecQualityMode: resp.RecQualityMode}, nil
+}
+
+// GetDeviceCapabilities returns the media the device can play and record.
+func (t AVTransport) GetDeviceCapabilities(ctx context.Context) (DeviceCapabilities, error) {
+	var resp struct {
+		PlayMedia       string
+		RecMedia        string
+		RecQualityModes string
+	}
+
+	if err := t.call(ctx, "GetDeviceCapabilities", InstanceID{}, &resp); err != nil {
+		return DeviceCapabilities{}, err
+	}
+
+	return DeviceCapabilities{
+		PlayMedia:       splitList(resp.PlayMedia),
+		RecMedia:        splitList(resp.RecMedia),
+		RecQualityModes: splitList(resp.RecQualityModes),
+	}, nil
+}
+
+// GetCurrentTransportActions returns the actions which can currently be
+// invoked, such as Play, Stop, Pause, Seek, Next and Previous.
+func (t AVTransport) GetCurrentTransportActions(ctx context.Context) ([]string, error) {
+	var resp struct {
+		Actions string
+	}
+
+	if err := t.call(ctx, "GetCurrentTransportActions", InstanceID{}, &resp); err != nil {
+		return nil, err
+	}
+
+	return splitList(resp.Actions), nil
+}
+
+// call invokes the action of the AVTransport service, see UpnpClient.Call.
+func (t AVTransport) call(ctx context.Context, action string, in, out interface{}) error {
+	return t.client.Call(ctx, AVTransportService, action, in, out)
+}
+
+// splitList splits a comma separated list, a value not implemented by the
+// device being an empty list.
+func splitList(value string) []string {
+	var list []string
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" && item != notImplemented {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+// parser converts the values of a response, keeping the first error.
+type parser struct {
+	err error
+}
+
+func (p *parser) int(name, value string) int {
+	if value == "" || value == notImplemented {
+		return 0
+	}
+
+	n, err := strconv.Atoi(value)
+
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+
+	return n
+}
+
+func (p *parser) duration(name, value string) time.Duration {
+	d, err := ParseDuration(value)
+
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	return d
+}
+
+var upnpDuration = regexp.MustCompile(`^([+-])?(\d+):([0-5]?\d):([0-5]?\d)(?:\.(\d+)(?:/(\d+))?)?$`)
+
+// ParseDuration parses a duration as reported by AVTransport, H+:MM:SS with
+// optional fractions of a second written either as decimals (.250) or as a
+// fraction (.1/4). Empty and NOT_IMPLEMENTED values are zero.
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+
+	if value == "" || value == notImplemented {
+		return 0, nil
+	}
+
+	m := upnpDuration.FindStringSubmatch(value)
+
+	if m == nil {
+		return 0, fmt.Errorf("%q is not a H:MM:SS duration", value)
+	}
+
+	hours, _ := strconv.Atoi(m[2])
+	minutes, _ := strconv.Atoi(m[3])
+	seconds, _ := strconv.Atoi(m[4])
+
+	d := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+
+	switch {
+	case m[6] != "":
+		numerator, _ := strconv.Atoi(m[5])
+		denominator, _ := strconv.Atoi(m[6])
+
+		if denominator == 0 || numerator >= denominator {
+			return 0, fmt.Errorf("%q has an invalid fraction of a second", value)
+		}
+
+		d += time.Second * time.Duration(numerator) / time.Duration(denominator)
+	case m[5] != "":
+		fraction, _ := strconv.ParseFloat("0."+m[5], 64)
+		d += time.Duration(fraction * float64(time.Second))
+	}
+
+	if m[1] == "-" {
+		d = -d
+	}
+
+	return d, nil
+}
+
+// FormatDuration formats the duration as H:MM:SS, with milliseconds when the
+// duration has any, as expected by Seek.
+func FormatDuration(d time.Duration) string {
+	sign := ""
+
+	if d < 0 {
+		sign, d = "-", -d
+	}
+
+	d = d.Round(time.Millisecond)
+	out := fmt.Sprintf("%s%d:%02d:%02d", sign, d/time.Hour, d/time.Minute%60, d/time.Second%60)
+
+	if ms := d / time.Millisecond % 1000; ms != 0 {
+		out += fmt.Sprintf(".%03d", ms)
+	}
+
+	return out
+}
@@ -0,0 +1,139 @@
+package upnp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAVTransport(t *testing.T) {
+	client, tv := newTestClient(t)
+	av := client.AVTransport()
+	ctx := context.Background()
+
+	// a url with a query must be escaped within the envelope.
+	uri := "http://example.com/video.mp4?a=1&b=2"
+	metadata := `<DIDL-Lite><item><dc:title>Video</dc:title></item></DIDL-Lite>`
+
+	assert.NoError(t, av.SetAVTransportURI(ctx, uri, metadata))
+	assert.NoError(t, av.SetNextAVTransportURI(ctx, "http://example.com/next.mp4", ""))
+	assert.NoError(t, av.Play(ctx))
+
+	info, err := av.GetTransportInfo(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, TransportInfo{State: StatePlaying, Status: "OK", Speed: "1"}, info)
+
+	media, err := av.GetMediaInfo(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, media.NrTracks)
+	assert.Equal(t, 3*time.Minute+25500*time.Millisecond, media.MediaDuration)
+	assert.Equal(t, uri, media.CurrentURI)
+	assert.Equal(t, metadata, media.CurrentURIMetaData)
+	assert.Equal(t, "http://example.com/next.mp4", media.NextURI)
+
+	assert.NoError(t, av.SeekTo(ctx, 90*time.Second))
+
+	position, err := av.GetPositionInfo(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, position.Track)
+	assert.Equal(t, 90*time.Second, position.RelTime)
+	assert.Equal(t, time.Duration(0), position.AbsTime)
+	assert.Equal(t, media.MediaDuration, position.TrackDuration)
+	assert.Equal(t, uri, position.TrackURI)
+
+	assert.NoError(t, av.SeekTrack(ctx, 2))
+
+	position, err = av.GetPositionInfo(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, position.Track)
+	assert.Equal(t, time.Duration(0), position.RelTime)
+
+	assert.NoError(t, av.Pause(ctx))
+
+	actions, err := av.GetCurrentTransportActions(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Play", "Stop", "Seek"}, actions)
+
+	assert.NoError(t, av.Stop(ctx))
+
+	info, err = av.GetTransportInfo(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, StateStopped, info.State)
+
+	seek := tv.SoapActions()[5]
+	assert.Equal(t, "Seek", seek.Action)
+	assert.Equal(t, "REL_TIME", seek.Arguments["Unit"])
+	assert.Equal(t, "0:01:30", seek.Arguments["Target"])
+}
+
+func TestAVTransportSettings(t *testing.T) {
+	client, _ := newTestClient(t)
+	av := client.AVTransport()
+	ctx := context.Background()
+
+	settings, err := av.GetTransportSettings(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, TransportSettings{PlayMode: PlayModeNormal, RecQualityMode: "NOT_IMPLEMENTED"}, settings)
+
+	assert.NoError(t, av.SetPlayMode(ctx, PlayModeRepeatAll))
+
+	settings, err = av.GetTransportSettings(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, PlayModeRepeatAll, settings.PlayMode)
+
+	var fault *SoapFault
+	assert.True(t, errors.As(av.SetPlayMode(ctx, PlayModeIntro), &fault))
+	assert.Equal(t, "712", fault.Code)
+
+	assert.True(t, errors.As(av.Seek(ctx, "FRAME", "10"), &fault))
+	assert.Equal(t, "710", fault.Code)
+
+	capabilities, err := av.GetDeviceCapabilities(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, DeviceCapabilities{PlayMedia: []string{"NETWORK", "UNKNOWN"}}, capabilities)
+
+	actions, err := av.GetCurrentTransportActions(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, actions)
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"0:00:00", 0},
+		{"00:03:25", 3*time.Minute + 25*time.Second},
+		{"1:02:03.500", time.Hour + 2*time.Minute + 3*time.Second + 500*time.Millisecond},
+		{"0:00:01.1/4", time.Second + 250*time.Millisecond},
+		{"-0:00:10", -10 * time.Second},
+		{"123:00:00", 123 * time.Hour},
+		{"NOT_IMPLEMENTED", 0},
+		{"", 0},
+	}
+
+	for _, test := range tests {
+		got, err := ParseDuration(test.value)
+		assert.NoError(t, err, test.value)
+		assert.Equal(t, test.want, got, test.value)
+	}
+
+	for _, value := range []string{"90", "0:60:00", "0:00:01.4/4", "1:00:00.x"} {
+		_, err := ParseDuration(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "0:00:00", FormatDuration(0))
+	assert.Equal(t, "0:01:30", FormatDuration(90*time.Second))
+	assert.Equal(t, "1:02:03.500", FormatDuration(time.Hour+2*time.Minute+3500*time.Millisecond))
+	assert.Equal(t, "-0:00:10", FormatDuration(-10*time.Second))
+
+	d, err := ParseDuration(FormatDuration(25*time.Hour + 1234*time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, 25*time.Hour+1234*time.Millisecond, d)
+}
@@ -1,8 +1,7 @@
 package upnp
 
 import (
-	"crypto/tls"
-	"encoding/json"
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
@@ -10,85 +9,57 @@ import (
 	"net"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
 	"time"
 
-	xj "github.com/basgys/goxml2json"
 	"github.com/stephensli/samsung-tv-api/pkg/device"
 )
 
 // This package covers the support for the Universal Plug & Play (UPNP)
 
+// DefaultTimeout bounds requests whose context carries no deadline, matching
+// the timeout of calls over the websocket api.
+const DefaultTimeout = 10 * time.Second
+
 type UpnpClient struct {
 	BaseUrl func(string) *url.URL
+	// Timeout bounds requests whose context has no deadline, DefaultTimeout
+	// is used when zero.
+	Timeout time.Duration
+	// HTTPClient is used to send requests when set.
+	HTTPClient *http.Client
 }
 
-// makeSoapRequest will send a API http call (soap) to the given endpoint (base url + protocol).
-// always being a POST. response will be converted to JSON and will be unmarshalled to the
-// output interface.
-//
-// TODO
-//   - support binding to a non 200 response or determine the error message returned and use it in the error response
-func (s *UpnpClient) makeSoapRequest(action, arguments, protocol string, output interface{}) error {
-	u := s.BaseUrl(protocol + "1").String()
-	fmt.Println(u)
-
-	body := fmt.Sprintf("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n"+
-		"<s:Envelope xmlns:s=\"http://schemas.xmlsoap.org/soap/envelope/\" s:encodingStyle=\"http://schemas.xmlsoap.org/soap/encoding/\">\n"+
-		"<s:Body>\n"+
-		"<u:%s xmlns:u=\"urn:schemas-upnp-org:service:%s:1\">\n"+
-		"<InstanceID>0</InstanceID>\n"+
-		"%s\n"+
-		"</u:%s>\n"+
-		"</s:Body>\n"+
-		"</s:Envelope>", action, protocol, arguments, action)
-
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		},
-	}
-
-	req, err := http.NewRequest("POST", u, strings.NewReader(body))
-	req.Header.Set("SOAPAction", fmt.Sprintf("\"urn:schemas-upnp-org:service:%s:1#%s\"", protocol, action))
-
-	if err != nil {
-		return err
-	}
-
-	resp, clientErr := client.Do(req)
-
-	if clientErr != nil {
-		return clientErr
-	}
-
-	defer resp.Body.Close()
-
-	content, convertErr := xj.Convert(resp.Body)
-
-	if convertErr != nil {
-		return convertErr
-	}
-
-	return json.Unmarshal(content.Bytes(), &output)
+// channel is the argument of the RenderingControl actions, Master being the
+// only channel of the TV.
+type channel struct {
+	InstanceID
+	Channel string
 }
 
+var master = channel{Channel: "Master"}
+
 // GetCurrentVolume returns the value of the current volume level
 //
 // TODO
 //   - This has to been tested with any bad input, should be regarded as not stable.
 func (s *UpnpClient) GetCurrentVolume() (int, error) {
+	return s.GetCurrentVolumeContext(context.Background())
+}
+
+// GetCurrentVolumeContext is GetCurrentVolume bound to the provided context.
+func (s *UpnpClient) GetCurrentVolumeContext(ctx context.Context) (int, error) {
 	log.Println("Get device volume via saop api")
 
-	output := GetDeviceVolumeResponse{}
-	err := s.makeSoapRequest("GetVolume", "<Channel>Master</Channel>", "RenderingControl", &output)
+	var output struct {
+		CurrentVolume int
+	}
 
-	if err != nil {
+	if err := s.Call(ctx, RenderingControlService, "GetVolume", master, &output); err != nil {
 		return -1, err
 	}
 
-	return strconv.Atoi(output.Envelope.Body.GetVolumeResponse.CurrentVolume)
+	return output.CurrentVolume, nil
 }
 
 // SetVolume will update the current volume of the display to the provided value.
@@ -96,12 +67,19 @@ func (s *UpnpClient) GetCurrentVolume() (int, error) {
 // TODO
 //   - This has to been tested with any bad input, should be regarded as not stable.
 func (s *UpnpClient) SetVolume(volume int) error {
+	return s.SetVolumeContext(context.Background(), volume)
+}
+
+// SetVolumeContext is SetVolume bound to the provided context.
+func (s *UpnpClient) SetVolumeContext(ctx context.Context, volume int) error {
 	log.Printf("set the volume of the tv to %d via soap api\n", volume)
 
-	var output interface{}
+	args := struct {
+		channel
+		DesiredVolume int
+	}{master, volume}
 
-	args := fmt.Sprintf("<Channel>Master</Channel><DesiredVolume>%d</DesiredVolume>", volume)
-	return s.makeSoapRequest("SetVolume", args, "RenderingControl", &output)
+	return s.Call(ctx, RenderingControlService, "SetVolume", args, nil)
 }
 
 // GetCurrentMuteStatus returns true if and only if the TV is currently muted
@@ -109,80 +87,94 @@ func (s *UpnpClient) SetVolume(volume int) error {
 // TODO
 //   - This has to been tested with any bad input, should be regarded as not stable.
 func (s *UpnpClient) GetCurrentMuteStatus() (bool, error) {
+	return s.GetCurrentMuteStatusContext(context.Background())
+}
+
+// GetCurrentMuteStatusContext is GetCurrentMuteStatus bound to the provided context.
+func (s *UpnpClient) GetCurrentMuteStatusContext(ctx context.Context) (bool, error) {
 	log.Println("Get device mute status via saop api")
 
-	output := GetCurrentMuteStatusResponse{}
-	err := s.makeSoapRequest("GetMute", "<Channel>Master</Channel>", "RenderingControl", &output)
+	var output struct {
+		CurrentMute string
+	}
 
-	if err != nil {
+	if err := s.Call(ctx, RenderingControlService, "GetMute", master, &output); err != nil {
 		return false, err
 	}
 
-	return output.Envelope.Body.GetMuteResponse.CurrentMute == "1", err
+	return output.CurrentMute == "1" || output.CurrentMute == "true", nil
 }
 
 // SetCurrentMedia will tell the display to play the current media via the URL.
 //
 // TODO
 //   - This has to been tested with any bad input, should be regarded as not stable.
-//   - This requires to be tested, it has not been ran to close any applications yet.
 func (s *UpnpClient) SetCurrentMedia(url string) error {
-	args := fmt.Sprintf("<CurrentURI>%s</CurrentURI><CurrentURIMetaData></CurrentURIMetaData>", url)
-
-	var output interface{}
-	var err error
-
-	err = s.makeSoapRequest("SetAVTransportURI", args, "AVTransport", &output)
+	return s.SetCurrentMediaContext(context.Background(), url)
+}
 
-	if err != nil {
+// SetCurrentMediaContext is SetCurrentMedia bound to the provided context.
+func (s *UpnpClient) SetCurrentMediaContext(ctx context.Context, url string) error {
+	if err := s.AVTransport().SetAVTransportURI(ctx, url, ""); err != nil {
 		return err
 	}
 
-	return s.PlayCurrentMedia()
+	return s.PlayCurrentMediaContext(ctx)
 }
 
-// GetCurrentMedia will return the status of the current media playing
+// GetCurrentMedia will return the status of the current media playing, see
+// AVTransport().GetTransportInfo for a typed response.
 //
 // TODO
 //   - This has to been tested with any bad input, should be regarded as not stable.
-//   - This requires to be tested, it has not been ran to close any applications yet.
 func (s *UpnpClient) GetCurrentMedia() (interface{}, error) {
-	var output interface{}
-	var err error
+	return s.GetCurrentMediaContext(context.Background())
+}
 
-	err = s.makeSoapRequest("GetTransportInfo", "", "AVTransport", &output)
+// GetCurrentMediaContext is GetCurrentMedia bound to the provided context,
+// the status being a TransportInfo.
+func (s *UpnpClient) GetCurrentMediaContext(ctx context.Context) (interface{}, error) {
+	info, err := s.AVTransport().GetTransportInfo(ctx)
 
 	if err != nil {
-		return err, nil
+		return nil, err
 	}
-	return output, nil
+
+	return info, nil
 }
 
-// GetPositionInfo will return the status of the current media playing
+// GetPositionInfo will return the status of the current media playing, see
+// AVTransport().GetPositionInfo for a typed response.
 func (s *UpnpClient) GetPositionInfo() (map[string]string, error) {
-	var output GetPositionInfoResponse
-	var err error
+	return s.GetPositionInfoContext(context.Background())
+}
 
-	err = s.makeSoapRequest("GetPositionInfo", "", "AVTransport", &output)
+// GetPositionInfoContext is GetPositionInfo bound to the provided context.
+func (s *UpnpClient) GetPositionInfoContext(ctx context.Context) (map[string]string, error) {
+	var output struct {
+		Track         string
+		RelTime       string
+		TrackDuration string
+		TrackMetaData string
+		TrackURI      string
+	}
 
-	if err != nil {
+	if err := s.Call(ctx, AVTransportService, "GetPositionInfo", InstanceID{}, &output); err != nil {
 		return nil, err
 	}
 
-	didlXml := strings.Replace(output.Envelope.Body.GetPositionResponse.TrackMetaData, "&quot;", "\"", -1)
-	didlXml = strings.Replace(didlXml, "&gt;", ">", -1)
-	didlXml = strings.Replace(didlXml, "&lt;", "<", -1)
-	didl := new(TrackMetaData_XML)
-	err = xml.Unmarshal([]byte(didlXml), &didl)
+	// the metadata is a DIDL-Lite document, absent when nothing is playing.
+	var didl TrackMetaData_XML
+	_ = xml.Unmarshal([]byte(output.TrackMetaData), &didl)
 
 	ret := map[string]string{
-		"Track":         output.Envelope.Body.GetPositionResponse.Track,
-		"RelTime":       output.Envelope.Body.GetPositionResponse.RelTime,
-		"TrackDuration": output.Envelope.Body.GetPositionResponse.TrackDuration,
+		"Track":         output.Track,
+		"RelTime":       output.RelTime,
+		"TrackDuration": output.TrackDuration,
 		"Artist":        didl.Item.Creator,
 		"Album":         didl.Item.Album,
 		"Cover":         didl.Item.AlbumArtUri,
-		"Uri":           output.Envelope.Body.GetPositionResponse.TrackURI,
+		"Uri":           output.TrackURI,
 	}
 	return ret, nil
 }
@@ -191,29 +183,43 @@ func (s *UpnpClient) GetPositionInfo() (map[string]string, error) {
 //
 // TODO
 //   - This has to been tested with any bad input, should be regarded as not stable.
-//   - This requires to be tested, it has not been ran to close any applications yet.
 func (s *UpnpClient) PlayCurrentMedia() error {
-	var output interface{}
-	return s.makeSoapRequest("Play", "<Speed>1</Speed>", "AVTransport", &output)
+	return s.PlayCurrentMediaContext(context.Background())
+}
+
+// PlayCurrentMediaContext is PlayCurrentMedia bound to the provided context.
+func (s *UpnpClient) PlayCurrentMediaContext(ctx context.Context) error {
+	return s.AVTransport().Play(ctx)
 }
 
 // Pause will attempt to pause playback.
 func (s *UpnpClient) Pause() error {
-	var output interface{}
-	err := s.makeSoapRequest("Pause", "<Speed>1</Speed>", "AVTransport", &output)
-	return err
+	return s.PauseContext(context.Background())
+}
+
+// PauseContext is Pause bound to the provided context.
+func (s *UpnpClient) PauseContext(ctx context.Context) error {
+	return s.AVTransport().Pause(ctx)
 }
 
 // PlayNext will attempt to play the next media in playlist.
 func (s *UpnpClient) PlayNext() error {
-	var output interface{}
-	return s.makeSoapRequest("Next", "", "AVTransport", &output)
+	return s.PlayNextContext(context.Background())
+}
+
+// PlayNextContext is PlayNext bound to the provided context.
+func (s *UpnpClient) PlayNextContext(ctx context.Context) error {
+	return s.AVTransport().Next(ctx)
 }
 
 // PlayPrev will attempt to play the next media in playlist.
 func (s *UpnpClient) PlayPrevious() error {
-	var output interface{}
-	return s.makeSoapRequest("Previous", "", "AVTransport", &output)
+	return s.PlayPreviousContext(context.Background())
+}
+
+// PlayPreviousContext is PlayPrevious bound to the provided context.
+func (s *UpnpClient) PlayPreviousContext(ctx context.Context) error {
+	return s.AVTransport().Previous(ctx)
 }
 
 func toMap(data []byte) map[string]string {
@@ -231,6 +237,15 @@ func toMap(data []byte) map[string]string {
 }
 
 func Discover(filter string, manufacturer string, devType string) []device.DeviceInfo {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return DiscoverContext(ctx, filter, manufacturer, devType)
+}
+
+// DiscoverContext is Discover bound to the provided context, responses are read
+// until the context deadline, or five seconds when it has no deadline.
+func DiscoverContext(ctx context.Context, filter string, manufacturer string, devType string) []device.DeviceInfo {
 	var found []device.DeviceInfo
 	ssdpAddress := "239.255.255.250:1900"
 
@@ -241,7 +256,17 @@ func Discover(filter string, manufacturer string, devType string) []device.Devic
 		return found
 	}
 	defer conn.Close()
-	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(5 * time.Second)
+	}
+	conn.SetReadDeadline(deadline)
+
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetReadDeadline(time.Now())
+	})
+	defer stop()
 
 	discoverMessage := []byte(
 		"M-SEARCH * HTTP/1.1\r\n" +
@@ -275,7 +300,7 @@ func Discover(filter string, manufacturer string, devType string) []device.Devic
 			continue
 		}
 		parsedURL, _ := url.Parse(data["LOCATION"])
-		props, err := DeviceProperties(data["LOCATION"])
+		props, err := DevicePropertiesContext(ctx, data["LOCATION"])
 		if err != nil {
 			log.Printf("%v", err)
 			continue
@@ -285,6 +310,7 @@ func Discover(filter string, manufacturer string, devType string) []device.Devic
 		}
 
 		d := device.DeviceInfo{
+			ID:   props.UDN,
 			Ip:   parsedURL.Hostname(),
 			Type: devType,
 			Mac:  props.MacAddress,
@@ -300,7 +326,16 @@ func Discover(filter string, manufacturer string, devType string) []device.Devic
 }
 
 func DeviceProperties(url string) (upnpDevice_XML, error) {
-	resp, err := http.Get(url)
+	return DevicePropertiesContext(context.Background(), url)
+}
+
+// DevicePropertiesContext is DeviceProperties bound to the provided context.
+func DevicePropertiesContext(ctx context.Context, url string) (upnpDevice_XML, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return upnpDevice_XML{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return upnpDevice_XML{}, err
 	}
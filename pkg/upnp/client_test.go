@@ -0,0 +1,94 @@
+package upnp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stephensli/samsung-tv-api/pkg/samsung-tv-api/samsungtvtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T) (*UpnpClient, *samsungtvtest.Server) {
+	tv := samsungtvtest.NewServer()
+	t.Cleanup(tv.Close)
+
+	return &UpnpClient{BaseUrl: tv.UpnpBaseUrl, Timeout: time.Second}, tv
+}
+
+func TestVolume(t *testing.T) {
+	client, tv := newTestClient(t)
+
+	assert.NoError(t, client.SetVolume(25))
+	assert.Equal(t, 25, tv.Volume())
+
+	volume, err := client.GetCurrentVolume()
+	assert.NoError(t, err)
+	assert.Equal(t, 25, volume)
+
+	actions := tv.SoapActions()
+	assert.Len(t, actions, 2)
+	assert.Equal(t, "RenderingControl", actions[0].Service)
+	assert.Equal(t, "SetVolume", actions[0].Action)
+	assert.Equal(t, "25", actions[0].Arguments["DesiredVolume"])
+}
+
+func TestCurrentMedia(t *testing.T) {
+	client, tv := newTestClient(t)
+
+	assert.NoError(t, client.SetCurrentMedia("http://example.com/video.mp4"))
+
+	info, err := client.GetPositionInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "http://example.com/video.mp4", info["Uri"])
+
+	state, err := client.GetCurrentMedia()
+	assert.NoError(t, err)
+	assert.Contains(t, fmt.Sprint(state), "PLAYING")
+
+	var names []string
+	for _, action := range tv.SoapActions() {
+		names = append(names, action.Action)
+	}
+
+	assert.Equal(t, []string{"SetAVTransportURI", "Play", "GetPositionInfo", "GetTransportInfo"}, names)
+}
+
+func TestUnknownActionFault(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	err := client.Call(context.Background(), AVTransportService, "Explode", InstanceID{}, nil)
+
+	var fault *SoapFault
+	assert.True(t, errors.As(err, &fault))
+	assert.Equal(t, "401", fault.Code)
+	assert.Equal(t, ErrorInvalidAction, fault.ErrorCode)
+}
+
+func TestSetVolumeCancelled(t *testing.T) {
+	client, tv := newTestClient(t)
+	tv.Latency = time.Second
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := client.SetVolumeContext(ctx, 25)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), tv.Latency)
+	assert.Equal(t, 10, tv.Volume())
+}
+
+func TestCallTimeout(t *testing.T) {
+	client, tv := newTestClient(t)
+	client.Timeout = 20 * time.Millisecond
+	tv.Latency = time.Second
+
+	start := time.Now()
+	err := client.SetVolume(25)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), tv.Latency)
+	assert.Equal(t, 10, tv.Volume())
+}
@@ -0,0 +1,85 @@
+package upnp
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UPnP error codes reported within SOAP faults, the codes from 700 being
+// specific to the service.
+const (
+	ErrorInvalidAction          = 401
+	ErrorInvalidArgs            = 402
+	ErrorActionFailed           = 501
+	ErrorArgumentValueInvalid   = 600
+	ErrorArgumentOutOfRange     = 601
+	ErrorActionNotImplemented   = 602
+	ErrorTransitionNotAvailable = 701
+	ErrorSeekModeNotSupported   = 710
+	ErrorIllegalSeekTarget      = 711
+	ErrorPlayModeNotSupported   = 712
+	ErrorIllegalMIMEType        = 714
+	ErrorInvalidInstanceID      = 718
+)
+
+// SoapFault is returned when the device responds to a SOAP action with a
+// fault. Code and Description hold the UPnP error code and description when
+// the device supplies them, falling back to the SOAP faultcode and faultstring.
+type SoapFault struct {
+	Code        string
+	Description string
+	// ErrorCode is the UPnP error code, such as ErrorInvalidArgs, zero when
+	// the device did not supply one.
+	ErrorCode int
+}
+
+func (f *SoapFault) Error() string {
+	return fmt.Sprintf("soap fault %s: %s", f.Code, f.Description)
+}
+
+type soapFaultEnvelope_XML struct {
+	Body struct {
+		Fault *struct {
+			FaultCode   string `xml:"faultcode"`
+			FaultString string `xml:"faultstring"`
+			Detail      struct {
+				UPnPError struct {
+					ErrorCode        string `xml:"errorCode"`
+					ErrorDescription string `xml:"errorDescription"`
+				} `xml:"UPnPError"`
+			} `xml:"detail"`
+		} `xml:"Fault"`
+	} `xml:"Body"`
+}
+
+// parseSoapFault returns the fault held within the response envelope, or nil
+// when the response is not a fault.
+func parseSoapFault(content []byte) *SoapFault {
+	var envelope soapFaultEnvelope_XML
+
+	if err := xml.Unmarshal(content, &envelope); err != nil || envelope.Body.Fault == nil {
+		return nil
+	}
+
+	fault := envelope.Body.Fault
+	result := &SoapFault{
+		Code:        strings.TrimSpace(fault.Detail.UPnPError.ErrorCode),
+		Description: strings.TrimSpace(fault.Detail.UPnPError.ErrorDescription),
+	}
+
+	if code, err := strconv.Atoi(result.Code); err == nil {
+		result.ErrorCode = code
+	}
+
+	if result.Code == "" {
+		result.Code = strings.TrimSpace(fault.FaultCode)
+	}
+
+	if result.Description == "" {
+		result.Description = strings.TrimSpace(fault.FaultString)
+	}
+
+	return result
+}
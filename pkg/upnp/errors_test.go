@@ -0,0 +1,43 @@
+package upnp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSoapFault(t *testing.T) {
+	fault := parseSoapFault([]byte(`<?xml version="1.0"?>
+<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/">
+<s:Body>
+<s:Fault>
+<faultcode>s:Client</faultcode>
+<faultstring>UPnPError</faultstring>
+<detail>
+<UPnPError xmlns="urn:schemas-upnp-org:control-1-0">
+<errorCode>718</errorCode>
+<errorDescription>Invalid InstanceID</errorDescription>
+</UPnPError>
+</detail>
+</s:Fault>
+</s:Body>
+</s:Envelope>`))
+
+	assert.Equal(t, &SoapFault{Code: "718", Description: "Invalid InstanceID", ErrorCode: ErrorInvalidInstanceID}, fault)
+}
+
+func TestParseSoapFaultWithoutUPnPError(t *testing.T) {
+	fault := parseSoapFault([]byte(`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/">
+<s:Body><s:Fault><faultcode>s:Server</faultcode><faultstring>Internal Error</faultstring></s:Fault></s:Body>
+</s:Envelope>`))
+
+	assert.Equal(t, &SoapFault{Code: "s:Server", Description: "Internal Error"}, fault)
+}
+
+func TestParseSoapFaultSuccessfulResponse(t *testing.T) {
+	fault := parseSoapFault([]byte(`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/">
+<s:Body><u:GetVolumeResponse xmlns:u="urn:schemas-upnp-org:service:RenderingControl:1"><CurrentVolume>12</CurrentVolume></u:GetVolumeResponse></s:Body>
+</s:Envelope>`))
+
+	assert.Nil(t, fault)
+}
@@ -2,6 +2,10 @@ package upnp
 
 import "encoding/xml"
 
+// GetDeviceVolumeResponse was the response of GetVolume converted to JSON.
+//
+// Deprecated: GetCurrentVolume returns the volume, and Call decodes the
+// arguments of a response into a struct of them.
 type GetDeviceVolumeResponse struct {
 	Envelope struct {
 		Body struct {
@@ -12,6 +16,10 @@ type GetDeviceVolumeResponse struct {
 	} `json:"Envelope"`
 }
 
+// GetCurrentMuteStatusResponse was the response of GetMute converted to JSON.
+//
+// Deprecated: GetCurrentMuteStatus returns the mute status, and Call decodes
+// the arguments of a response into a struct of them.
 type GetCurrentMuteStatusResponse struct {
 	Envelope struct {
 		Body struct {
@@ -22,6 +30,10 @@ type GetCurrentMuteStatusResponse struct {
 	} `json:"Envelope"`
 }
 
+// GetPositionInfoResponse was the response of GetPositionInfo converted to
+// JSON.
+//
+// Deprecated: use AVTransport().GetPositionInfo, which returns a PositionInfo.
 type GetPositionInfoResponse struct {
 	Envelope struct {
 		Body struct {
@@ -42,6 +54,7 @@ type upnpDescribeDevice_XML struct {
 }
 
 type upnpDevice_XML struct {
+	UDN              string `xml:"UDN"`
 	FriendlyName     string `xml:"friendlyName"`
 	Manufacturer     string `xml:"manufacturer"`
 	ModelNumber      string `xml:"modelNumber"`
@@ -0,0 +1,93 @@
+package upnp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCall(t *testing.T) {
+	client, tv := newTestClient(t)
+	ctx := context.Background()
+
+	in := struct {
+		InstanceID
+		Channel       string
+		DesiredVolume int `xml:"DesiredVolume"`
+	}{Channel: "Master", DesiredVolume: 30}
+
+	assert.NoError(t, client.Call(ctx, RenderingControlService, "SetVolume", in, nil))
+
+	var out struct {
+		Volume int `xml:"CurrentVolume"`
+	}
+
+	assert.NoError(t, client.Call(ctx, RenderingControlService, "GetVolume", master, &out))
+	assert.Equal(t, 30, out.Volume)
+
+	action := tv.SoapActions()[0]
+	assert.Equal(t, "urn:schemas-upnp-org:service:RenderingControl:1", action.URN)
+	assert.Equal(t, map[string]string{"InstanceID": "0", "Channel": "Master", "DesiredVolume": "30"}, action.Arguments)
+}
+
+func TestCallServiceVersion(t *testing.T) {
+	client, tv := newTestClient(t)
+
+	service := NewService("AVTransport", 2)
+	assert.Equal(t, Service{URN: "urn:schemas-upnp-org:service:AVTransport:2", Control: "AVTransport1"}, service)
+
+	var out struct {
+		CurrentTransportState TransportState
+	}
+
+	assert.NoError(t, client.Call(context.Background(), service, "GetTransportInfo", InstanceID{}, &out))
+	assert.Equal(t, StateNoMediaPresent, out.CurrentTransportState)
+	assert.Equal(t, service.URN, tv.SoapActions()[0].URN)
+}
+
+func TestCallEscapesArguments(t *testing.T) {
+	client, tv := newTestClient(t)
+
+	uri := "http://example.com/video.mp4?a=1&b=<2>"
+	assert.NoError(t, client.SetCurrentMedia(uri))
+
+	assert.Equal(t, uri, tv.SoapActions()[0].Arguments["CurrentURI"])
+
+	info, err := client.GetPositionInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uri, info["Uri"])
+}
+
+func TestCallFault(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	// every action of the TV requires an InstanceID.
+	err := client.Call(context.Background(), AVTransportService, "Stop", nil, nil)
+
+	var fault *SoapFault
+	assert.True(t, errors.As(err, &fault))
+	assert.Equal(t, ErrorInvalidArgs, fault.ErrorCode)
+	assert.Equal(t, "Invalid Args", fault.Description)
+}
+
+func TestMarshalEnvelope(t *testing.T) {
+	body, err := marshalEnvelope(AVTransportService, "Stop", InstanceID{})
+	assert.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">`+
+		`<s:Body><u:Stop xmlns:u="urn:schemas-upnp-org:service:AVTransport:1"><InstanceID>0</InstanceID></u:Stop></s:Body></s:Envelope>`,
+		string(body))
+
+	body, err = marshalEnvelope(AVTransportService, "Stop", nil)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `<u:Stop xmlns:u="urn:schemas-upnp-org:service:AVTransport:1"></u:Stop>`)
+}
+
+func TestUnmarshalResponseMissing(t *testing.T) {
+	var out struct{ CurrentVolume int }
+
+	err := unmarshalResponse([]byte(`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body></s:Body></s:Envelope>`), "GetVolume", &out)
+	assert.EqualError(t, err, "soap response of GetVolume has no GetVolumeResponse")
+}